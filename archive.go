@@ -0,0 +1,220 @@
+package bsdata
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/osfs"
+	"github.com/go-git/go-billy/v5/util"
+)
+
+// ArchiveFormat is the kind of release archive downloaded by a client
+// created with WithArchives.
+type ArchiveFormat int
+
+const (
+	// ArchiveZip downloads .zip archives.
+	ArchiveZip ArchiveFormat = iota
+	// ArchiveTarGz downloads .tar.gz archives.
+	ArchiveTarGz
+)
+
+func (f ArchiveFormat) ext() string {
+	if f == ArchiveTarGz {
+		return ".tar.gz"
+	}
+
+	return ".zip"
+}
+
+// WithArchives makes the client download the release archive for a ref over
+// HTTP instead of cloning the repository with git. Archives are requested
+// from <repo URL>/archive/<ref><ext> as served by GitHub, and only the data
+// files are extracted from them. It takes precedence over WithCache.
+func WithArchives(format ArchiveFormat) Option {
+	return func(c *Client) {
+		c.archives = &format
+	}
+}
+
+// archiveURL returns the URL of the archive of repo at ref.
+func (c *Client) archiveURL(repo string, ref Ref) string {
+	base := strings.TrimSuffix(c.remoteURL(repo), ".git")
+
+	var name string
+	switch ref.Kind {
+	case RefTag:
+		name = "refs/tags/" + ref.Name
+	case RefBranch:
+		name = "refs/heads/" + ref.Name
+	case RefCommit:
+		name = ref.Name
+	default:
+		name = "HEAD"
+	}
+
+	return base + "/archive/" + name + c.archives.ext()
+}
+
+// downloadArchive fetches the release archive of repo at ref and extracts
+// its data files into dir, or into memory in memory mode. It returns the
+// commit recorded in the archive, if any.
+func (c *Client) downloadArchive(ctx context.Context, repo string, ref Ref, dir string) (fs.FS, string, error) {
+	url := c.archiveURL(repo, ref)
+	c.logger.Info("downloading archive", "repo", repo, "ref", ref.String(), "url", url)
+
+	res, err := c.httpGet(ctx, url)
+	var herr *HTTPError
+	switch {
+	case errors.As(err, &herr) && herr.StatusCode == http.StatusNotFound:
+		return nil, "", ref.notFound(repo)
+	case errors.As(err, &herr) && (herr.StatusCode == http.StatusUnauthorized || herr.StatusCode == http.StatusForbidden):
+		return nil, "", fmt.Errorf("%w: %s", ErrRepoNotFound, repo)
+	case err != nil:
+		return nil, "", err
+	}
+	defer res.Body.Close()
+
+	var wt billy.Filesystem
+	var fsys fs.FS
+	if c.inMemory {
+		wt = memfs.New()
+		fsys = billyFS{wt}
+	} else {
+		wt = osfs.New(dir)
+		fsys = os.DirFS(dir)
+	}
+
+	var commit string
+	if *c.archives == ArchiveTarGz {
+		commit, err = extractTarGz(ctx, res.Body, wt)
+	} else {
+		commit, err = extractZip(ctx, res.Body, wt)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	if commit == "" && ref.Kind == RefCommit {
+		commit = ref.Name
+	}
+
+	return fsys, commit, nil
+}
+
+// archivePath strips the top-level directory that release archives wrap
+// their contents in. It returns false for entries that should be skipped.
+func archivePath(name string) (string, bool) {
+	name = strings.TrimPrefix(path.Clean("/"+name), "/")
+	i := strings.IndexByte(name, '/')
+	if i < 0 {
+		return "", false
+	}
+
+	name = name[i+1:]
+
+	return name, fs.ValidPath(name) && isDataFile(name)
+}
+
+// extractZip writes the data files in the zip archive read from r to wt and
+// returns the commit GitHub records in the archive comment.
+func extractZip(ctx context.Context, r io.Reader, wt billy.Filesystem) (string, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		return "", err
+	}
+
+	for _, f := range zr.File {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+
+		name, ok := archivePath(f.Name)
+		if !ok || f.FileInfo().IsDir() {
+			continue
+		}
+
+		if err := extractFile(wt, name, f.Open); err != nil {
+			return "", err
+		}
+	}
+
+	return strings.TrimSpace(zr.Comment), nil
+}
+
+// extractTarGz writes the data files in the gzipped tar archive read from r
+// to wt and returns the commit GitHub records in the global header.
+func extractTarGz(ctx context.Context, r io.Reader, wt billy.Filesystem) (string, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return "", err
+	}
+	defer gz.Close()
+
+	var commit string
+	tr := tar.NewReader(gz)
+	for {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+
+		if hdr.Typeflag == tar.TypeXGlobalHeader {
+			commit = hdr.PAXRecords["comment"]
+			continue
+		}
+
+		name, ok := archivePath(hdr.Name)
+		if !ok || hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		open := func() (io.ReadCloser, error) { return ioutil.NopCloser(tr), nil }
+		if err := extractFile(wt, name, open); err != nil {
+			return "", err
+		}
+	}
+
+	return commit, nil
+}
+
+func extractFile(wt billy.Filesystem, name string, open func() (io.ReadCloser, error)) error {
+	r, err := open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	return util.WriteFile(wt, name, b, 0644)
+}
@@ -0,0 +1,136 @@
+package bsdata_test
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/myminicommission/go-bsdata"
+)
+
+const archiveCommit = "0123456789abcdef0123456789abcdef01234567"
+
+func TestClientArchives(t *testing.T) {
+	files := map[string][]byte{
+		"test-repo-1.0.0/Test-Faction.cat": readFixture(t, "Test-Faction.cat"),
+		"test-repo-1.0.0/Test-Library.cat": readFixture(t, "Test-Library.cat"),
+		"test-repo-1.0.0/Test-System.gst":  readFixture(t, "Test-System.gst"),
+		"test-repo-1.0.0/README.md":        []byte("not data"),
+	}
+
+	archives := map[string][]byte{
+		"/BSData/test-repo/archive/refs/tags/1.0.0.zip":    zipArchive(t, files),
+		"/BSData/test-repo/archive/refs/tags/1.0.0.tar.gz": tarGzArchive(t, files),
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, ok := archives[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(b)
+	}))
+	defer srv.Close()
+
+	for name, format := range map[string]bsdata.ArchiveFormat{"zip": bsdata.ArchiveZip, "tar.gz": bsdata.ArchiveTarGz} {
+		t.Run(name, func(t *testing.T) {
+			client := bsdata.NewClient(
+				bsdata.WithBaseURL(srv.URL+"/BSData"),
+				bsdata.WithWorkDir(t.TempDir()),
+				bsdata.WithArchives(format),
+			)
+
+			catalogues, commit, err := client.GetDataRef(context.Background(), "test-repo", bsdata.Tag("1.0.0"))
+			if err != nil {
+				t.Error(err)
+				t.FailNow()
+			}
+
+			if len(catalogues) != 2 {
+				t.Errorf("expected 2 catalogues, found %d", len(catalogues))
+			}
+
+			if commit != archiveCommit {
+				t.Errorf("expected commit %s, got %q", archiveCommit, commit)
+			}
+
+			_, err = client.GetData("test-repo", "9.9.9")
+			if !errors.Is(err, bsdata.ErrTagNotFound) {
+				t.Errorf("expected ErrTagNotFound, got %v", err)
+			}
+		})
+	}
+}
+
+func zipArchive(t *testing.T, files map[string][]byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, b := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := zw.SetComment(archiveCommit); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func tarGzArchive(t *testing.T, files map[string][]byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	err := tw.WriteHeader(&tar.Header{
+		Typeflag:   tar.TypeXGlobalHeader,
+		Name:       "pax_global_header",
+		PAXRecords: map[string]string{"comment": archiveCommit},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, b := range files {
+		err := tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     name,
+			Mode:     0644,
+			Size:     int64(len(b)),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
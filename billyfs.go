@@ -0,0 +1,121 @@
+package bsdata
+
+import (
+	"io"
+	"io/fs"
+	"os"
+
+	"github.com/go-git/go-billy/v5"
+)
+
+// billyFS exposes a go-billy filesystem, such as go-git's in-memory
+// worktree, as an fs.FS so it can be parsed like any other source.
+type billyFS struct {
+	fs billy.Filesystem
+}
+
+func (b billyFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+
+	info, err := b.fs.Stat(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	if info.IsDir() {
+		return &billyDir{fs: b, name: name, info: info}, nil
+	}
+
+	f, err := b.fs.Open(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	return &billyFile{File: f, info: info}, nil
+}
+
+func (b billyFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+
+	infos, err := b.fs.ReadDir(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: err}
+	}
+
+	entries := make([]fs.DirEntry, len(infos))
+	for i, info := range infos {
+		entries[i] = dirEntry{info}
+	}
+
+	return entries, nil
+}
+
+type billyFile struct {
+	billy.File
+	info os.FileInfo
+}
+
+func (f *billyFile) Stat() (fs.FileInfo, error) {
+	return f.info, nil
+}
+
+type billyDir struct {
+	fs      billyFS
+	name    string
+	info    os.FileInfo
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (d *billyDir) Stat() (fs.FileInfo, error) {
+	return d.info, nil
+}
+
+func (d *billyDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.name, Err: fs.ErrInvalid}
+}
+
+func (d *billyDir) Close() error {
+	return nil
+}
+
+func (d *billyDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	if d.entries == nil {
+		entries, err := d.fs.ReadDir(d.name)
+		if err != nil {
+			return nil, err
+		}
+		d.entries = entries
+	}
+
+	rest := d.entries[d.offset:]
+	if n <= 0 {
+		d.offset = len(d.entries)
+		return rest, nil
+	}
+
+	if len(rest) == 0 {
+		return nil, io.EOF
+	}
+
+	if n > len(rest) {
+		n = len(rest)
+	}
+	d.offset += n
+
+	return rest[:n], nil
+}
+
+// dirEntry adapts an os.FileInfo to fs.DirEntry.
+type dirEntry struct {
+	info os.FileInfo
+}
+
+func (e dirEntry) Name() string               { return e.info.Name() }
+func (e dirEntry) IsDir() bool                { return e.info.IsDir() }
+func (e dirEntry) Type() fs.FileMode          { return e.info.Mode().Type() }
+func (e dirEntry) Info() (fs.FileInfo, error) { return e.info, nil }
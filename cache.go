@@ -0,0 +1,309 @@
+package bsdata
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// mirrorRefSpecs keep the cached bare repositories' branches and tags in step
+// with the remote, as `git clone --mirror` would. The remote's HEAD is kept
+// as origin/HEAD, where resolveRef looks for the default branch.
+var mirrorRefSpecs = []config.RefSpec{
+	"+refs/heads/*:refs/heads/*",
+	"+refs/tags/*:refs/tags/*",
+	"+HEAD:refs/remotes/origin/HEAD",
+}
+
+// CacheConfig configures the on-disk repository cache enabled by WithCache.
+type CacheConfig struct {
+	// Dir is where the bare repositories are kept, one per data repository.
+	// It defaults to ./bsdata-cache.
+	Dir string
+	// MaxSize is the total size in bytes the cache may grow to before the
+	// least recently used repositories are evicted. Zero means no limit.
+	MaxSize int64
+	// MaxAge is how long a repository may go unused before it is evicted.
+	// Zero means repositories never expire.
+	MaxAge time.Duration
+}
+
+// WithCache keeps a bare clone of every repository the client fetches so
+// that later calls only need to fetch new refs.
+func WithCache(cfg CacheConfig) Option {
+	if cfg.Dir == "" {
+		cfg.Dir = cacheDirectory
+	}
+
+	return func(c *Client) {
+		c.cache = &cfg
+	}
+}
+
+func (cfg *CacheConfig) repoPath(repo string) string {
+	return filepath.Join(cfg.Dir, repoKey(repo)+".git")
+}
+
+// checkoutCached brings the cached bare repository for repo up to date and
+// writes the tree at ref into dir.
+func (c *Client) checkoutCached(ctx context.Context, repo string, ref Ref, dir string) (fs.FS, string, error) {
+	hash, err := c.checkoutCachedLocked(ctx, repo, ref, dir)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// the fetch succeeded, so a failed eviction is only worth a log line
+	if err := c.evict(repoKey(repo)); err != nil {
+		c.logger.Info("evicting cached repositories failed", "repo", repo, "error", err)
+	}
+
+	return os.DirFS(dir), hash, nil
+}
+
+func (c *Client) checkoutCachedLocked(ctx context.Context, repo string, ref Ref, dir string) (string, error) {
+	unlock := c.lockRepo(repoKey(repo))
+	defer unlock()
+
+	r, err := c.updateCache(ctx, repo)
+	if err != nil {
+		return "", err
+	}
+
+	commit, err := resolveRef(r, repo, ref)
+	if err != nil {
+		return "", err
+	}
+
+	c.logger.Info("checking out from cache", "repo", repo, "ref", ref.String(), "commit", commit.Hash.String())
+
+	return commit.Hash.String(), writeTree(ctx, commit, dir)
+}
+
+// lockRepo serialises access to the cached bare repository with the given
+// key and returns the function that releases it.
+func (c *Client) lockRepo(key string) func() {
+	mu, _ := c.repoLocks.LoadOrStore(key, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+
+	return mu.(*sync.Mutex).Unlock
+}
+
+// updateCache creates the cached bare repository for repo if needed and
+// fetches its branches and tags into it with mirrorRefSpecs.
+func (c *Client) updateCache(ctx context.Context, repo string) (*git.Repository, error) {
+	url := c.remoteURL(repo)
+	path := c.cache.repoPath(repo)
+
+	auth, err := c.authMethod(url)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := git.PlainOpen(path)
+	created := false
+	switch {
+	case errors.Is(err, git.ErrRepositoryNotExists):
+		c.logger.Info("caching repository", "repo", repo, "url", url)
+		if r, err = initCache(path, url); err != nil {
+			os.RemoveAll(path)
+			return nil, err
+		}
+		created = true
+	case err != nil:
+		return nil, err
+	default:
+		c.logger.Info("fetching cached repository", "repo", repo, "url", url)
+	}
+
+	err = r.FetchContext(ctx, &git.FetchOptions{
+		RefSpecs: mirrorRefSpecs,
+		Auth:     auth,
+		Progress: c.progress.sideband(),
+		Tags:     git.AllTags,
+		Force:    true,
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		// don't leave an empty repository behind for the next call
+		if created {
+			os.RemoveAll(path)
+		}
+		return nil, cloneErr(err, repo)
+	}
+
+	// mark the repository as recently used
+	now := time.Now()
+	if err := os.Chtimes(path, now, now); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// initCache creates an empty bare repository at path whose origin remote
+// fetches url with mirrorRefSpecs.
+func initCache(path, url string) (*git.Repository, error) {
+	r, err := git.PlainInit(path, true)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = r.CreateRemote(&config.RemoteConfig{
+		Name:  git.DefaultRemoteName,
+		URLs:  []string{url},
+		Fetch: mirrorRefSpecs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// writeTree writes every file in commit's tree below dir.
+func writeTree(ctx context.Context, commit *object.Commit, dir string) error {
+	tree, err := commit.Tree()
+	if err != nil {
+		return err
+	}
+
+	return tree.Files().ForEach(func(f *object.File) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		path := filepath.Join(dir, filepath.FromSlash(f.Name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+
+		r, err := f.Reader()
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+
+		out, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+
+		if _, err := io.Copy(out, r); err != nil {
+			out.Close()
+			return err
+		}
+
+		return out.Close()
+	})
+}
+
+// cacheEntry is a cached bare repository considered for eviction.
+type cacheEntry struct {
+	name   string
+	path   string
+	size   int64
+	usedAt time.Time
+}
+
+// evict removes expired repositories and then the least recently used ones
+// until the cache fits in MaxSize. The repository named keep is never
+// evicted, and repositories are locked while they are removed.
+func (c *Client) evict(keep string) error {
+	cfg := c.cache
+	if cfg.MaxSize <= 0 && cfg.MaxAge <= 0 {
+		return nil
+	}
+
+	infos, err := os.ReadDir(cfg.Dir)
+	if err != nil {
+		return err
+	}
+
+	var entries []cacheEntry
+	var total int64
+	for _, info := range infos {
+		if !info.IsDir() || !strings.HasSuffix(info.Name(), ".git") {
+			continue
+		}
+
+		fi, err := info.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		e := cacheEntry{
+			name:   strings.TrimSuffix(info.Name(), ".git"),
+			path:   filepath.Join(cfg.Dir, info.Name()),
+			usedAt: fi.ModTime(),
+		}
+		if e.size, err = dirSize(e.path); err != nil {
+			return err
+		}
+
+		entries = append(entries, e)
+		total += e.size
+	}
+
+	// oldest first
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].usedAt.Before(entries[j].usedAt)
+	})
+
+	for _, e := range entries {
+		if e.name == keep {
+			continue
+		}
+
+		expired := cfg.MaxAge > 0 && time.Since(e.usedAt) > cfg.MaxAge
+		oversize := cfg.MaxSize > 0 && total > cfg.MaxSize
+		if !expired && !oversize {
+			continue
+		}
+
+		unlock := c.lockRepo(e.name)
+		err := os.RemoveAll(e.path)
+		unlock()
+		if err != nil {
+			return err
+		}
+		total -= e.size
+	}
+
+	return nil
+}
+
+// dirSize adds up the size of the files below path. Files that disappear
+// during the walk, such as go-git's temporary packs in a repository being
+// fetched by another call, are skipped.
+func dirSize(path string) (int64, error) {
+	var size int64
+	err := filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if !info.IsDir() {
+			size += info.Size()
+		}
+
+		return nil
+	})
+
+	return size, err
+}
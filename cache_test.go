@@ -0,0 +1,116 @@
+package bsdata_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+
+	"github.com/myminicommission/go-bsdata"
+)
+
+func TestClientCacheFetchesNewTags(t *testing.T) {
+	base := newTestRemote(t, "test-repo", "1.0.0")
+	cacheDir := t.TempDir()
+	client := bsdata.NewClient(
+		bsdata.WithBaseURL("file://"+base),
+		bsdata.WithWorkDir(t.TempDir()),
+		bsdata.WithCache(bsdata.CacheConfig{Dir: cacheDir}),
+	)
+
+	if _, err := client.GetData("test-repo", "1.0.0"); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if _, err := os.Stat(filepath.Join(cacheDir, "test-repo.git")); err != nil {
+		t.Errorf("expected a cached bare repository: %v", err)
+	}
+
+	// publish a new release with an extra catalogue
+	r, err := git.PlainOpen(filepath.Join(base, "test-repo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	commitTestFiles(t, r, map[string][]byte{
+		"Extra.cat": readFixture(t, "Test-Library.cat"),
+	}, "1.1.0")
+
+	catalogues, err := client.GetData("test-repo", "1.1.0")
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if len(catalogues) != 3 {
+		t.Errorf("expected 3 catalogues at 1.1.0, found %d", len(catalogues))
+	}
+
+	catalogues, err = client.GetData("test-repo", "1.0.0")
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if len(catalogues) != 2 {
+		t.Errorf("expected 2 catalogues at 1.0.0, found %d", len(catalogues))
+	}
+}
+
+func TestClientCacheEviction(t *testing.T) {
+	base := newTestRemote(t, "first", "1.0.0")
+	addTestRepo(t, base, "second", "1.0.0")
+	cacheDir := t.TempDir()
+	client := bsdata.NewClient(
+		bsdata.WithBaseURL("file://"+base),
+		bsdata.WithWorkDir(t.TempDir()),
+		bsdata.WithCache(bsdata.CacheConfig{Dir: cacheDir, MaxSize: 1}),
+	)
+
+	for _, repo := range []string{"first", "second"} {
+		if _, err := client.GetData(repo, "1.0.0"); err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(cacheDir, "first.git")); !os.IsNotExist(err) {
+		t.Error("expected the least recently used repository to be evicted")
+	}
+
+	if _, err := os.Stat(filepath.Join(cacheDir, "second.git")); err != nil {
+		t.Errorf("expected the most recent repository to be kept: %v", err)
+	}
+}
+
+func TestClientCacheDefaultDir(t *testing.T) {
+	base := newTestRemote(t, "test-repo", "1.0.0")
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		os.Chdir(wd)
+	})
+
+	client := bsdata.NewClient(
+		bsdata.WithBaseURL("file://"+base),
+		bsdata.WithWorkDir(t.TempDir()),
+		bsdata.WithCache(bsdata.CacheConfig{MaxSize: 1}),
+	)
+
+	if _, err := client.GetData("test-repo", "1.0.0"); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "bsdata-cache", "test-repo.git")); err != nil {
+		t.Errorf("expected the repository to be cached in bsdata-cache: %v", err)
+	}
+}
@@ -1,6 +1,8 @@
 package bsdata_test
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/myminicommission/go-bsdata"
@@ -27,3 +29,13 @@ func TestGetData(t *testing.T) {
 
 	t.Logf("Found %d cat files", len(catalogues))
 }
+
+func TestGetDataContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := bsdata.GetDataContext(ctx, "star-wars-legion", "1.7.0")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,396 @@
+package bsdata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/storage/memory"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+const (
+	baseDataRepoURL = "https://github.com/BSData"
+	directory       = "./checkout-tmp"
+	cacheDirectory  = "./bsdata-cache"
+)
+
+// Client fetches and parses Battlescribe data repositories. A Client is
+// configured with Options when it is created; differently configured
+// clients can be used side by side.
+type Client struct {
+	baseURL   string
+	workDir   string
+	logger    Logger
+	auth      transport.AuthMethod
+	transport http.RoundTripper
+	cache     *CacheConfig
+	inMemory  bool
+	archives  *ArchiveFormat
+	workers   int
+	progress  *progressReporter
+	discovery DiscoverOptions
+	strict    bool
+
+	hostKeyCallback gossh.HostKeyCallback
+
+	flights   flightGroup
+	repoLocks sync.Map
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithBaseURL sets the URL that repository names are appended to when
+// cloning. It defaults to https://github.com/BSData. Repositories given as
+// full URLs do not use it.
+func WithBaseURL(url string) Option {
+	return func(c *Client) {
+		c.baseURL = url
+	}
+}
+
+// WithWorkDir sets the directory that repositories are checked out into.
+// It defaults to ./checkout-tmp.
+func WithWorkDir(dir string) Option {
+	return func(c *Client) {
+		c.workDir = dir
+	}
+}
+
+// WithLogger sets the logger the client reports what it is doing to. A
+// *slog.Logger can be passed directly; use LogrusLogger for logrus. By
+// default nothing is logged.
+func WithLogger(logger Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithAuth sets the go-git credentials used when talking to the remote,
+// such as http.BasicAuth or an ssh.PublicKeys key.
+func WithAuth(auth transport.AuthMethod) Option {
+	return func(c *Client) {
+		c.auth = auth
+	}
+}
+
+// WithHTTPTransport sets the round tripper used for HTTP(S) git requests.
+//
+// go-git only has one transport per URL scheme for the whole process, so
+// importing this package installs a dispatching transport for http and https
+// with client.InstallProtocol. Requests from other code keep going to the
+// transport that was there before. If the dispatcher is later replaced, git
+// requests from the client fail with ErrTransportReplaced.
+func WithHTTPTransport(rt http.RoundTripper) Option {
+	return func(c *Client) {
+		c.transport = rt
+	}
+}
+
+// WithInMemory makes the client clone into memory instead of its work
+// directory, so fetching data never writes to the filesystem. It takes
+// precedence over WithCache.
+func WithInMemory() Option {
+	return func(c *Client) {
+		c.inMemory = true
+	}
+}
+
+// WithWorkers sets how many data files are parsed at once. It defaults to
+// GOMAXPROCS.
+func WithWorkers(n int) Option {
+	return func(c *Client) {
+		c.workers = n
+	}
+}
+
+// NewClient returns a Client configured with opts.
+func NewClient(opts ...Option) *Client {
+	c := &Client{
+		baseURL: baseDataRepoURL,
+		workDir: directory,
+		logger:  nopLogger{},
+		workers: runtime.GOMAXPROCS(0),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.applyHostKeyCallback()
+
+	return c
+}
+
+var defaultClient = NewClient()
+
+// GetData fetches the Battlescribe data for the BSData/wh40k repo
+func GetData(repo, tag string) ([]*Catalogue, error) {
+	return defaultClient.GetData(repo, tag)
+}
+
+// GetDataContext is like GetData but stops cloning, checking out and parsing
+// as soon as ctx is cancelled or its deadline passes. Unless another caller
+// is still waiting on the same fetch, any partial checkout is removed before
+// it returns.
+func GetDataContext(ctx context.Context, repo, tag string) ([]*Catalogue, error) {
+	return defaultClient.GetDataContext(ctx, repo, tag)
+}
+
+// GetDataRef fetches the Battlescribe data for repo at ref and reports the
+// commit hash ref resolved to.
+func GetDataRef(ctx context.Context, repo string, ref Ref) ([]*Catalogue, string, error) {
+	return defaultClient.GetDataRef(ctx, repo, ref)
+}
+
+// GetDataset fetches the Battlescribe data for repo at ref along with where
+// and when it came from.
+func GetDataset(ctx context.Context, repo string, ref Ref) (*Dataset, error) {
+	return defaultClient.GetDataset(ctx, repo, ref)
+}
+
+// GetData fetches the Battlescribe data for repo at tag.
+func (c *Client) GetData(repo, tag string) ([]*Catalogue, error) {
+	return c.GetDataContext(context.Background(), repo, tag)
+}
+
+// GetDataContext is like GetData but honours cancellation of ctx. Each call
+// checks out into its own directory below the work directory, and concurrent
+// calls for the same repo and tag share a single fetch and its result.
+func (c *Client) GetDataContext(ctx context.Context, repo, tag string) ([]*Catalogue, error) {
+	catalogues, _, err := c.GetDataRef(ctx, repo, tagRef(tag))
+	return catalogues, err
+}
+
+// GetDataRef fetches the Battlescribe data for repo at ref, which may be a
+// tag, a branch or a commit. It also returns the hash of the commit that
+// ref resolved to. If some files fail to parse, the remaining catalogues
+// are returned together with a LoadErrors.
+func (c *Client) GetDataRef(ctx context.Context, repo string, ref Ref) ([]*Catalogue, string, error) {
+	ds, err := c.GetDataset(ctx, repo, ref)
+	if ds == nil {
+		return nil, "", err
+	}
+
+	return ds.Catalogues, ds.Commit, err
+}
+
+// GetDataset fetches the Battlescribe data for repo at ref along with where
+// and when it came from. If some files fail to parse, the dataset holding
+// the rest is returned together with a LoadErrors. Concurrent calls for the
+// same repo and ref share the same *Dataset, which must not be modified.
+func (c *Client) GetDataset(ctx context.Context, repo string, ref Ref) (*Dataset, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return c.flights.do(ctx, repo+"@"+ref.String(), func(ctx context.Context) (*Dataset, error) {
+		return c.getData(ctx, repo, ref)
+	})
+}
+
+func (c *Client) getData(ctx context.Context, repo string, ref Ref) (*Dataset, error) {
+	c.logger.Info("getting catalogues", "repo", repo, "ref", ref.String())
+
+	var ds *Dataset
+	err := c.withCheckout(ctx, repo, ref, func(fsys fs.FS, commit string) error {
+		fetchedAt := time.Now()
+
+		// parse the checked out data files
+		// files that failed to parse are reported alongside the rest
+		l := c.loader()
+		l.logger = withFields(l.logger, "repo", repo, "ref", ref.String())
+
+		var err error
+		if ds, err = l.load(ctx, fsys); ds != nil {
+			ds.Repo = repo
+			ds.Ref = ref
+			ds.Commit = commit
+			ds.FetchedAt = fetchedAt
+		}
+
+		return err
+	})
+
+	return ds, err
+}
+
+// withCheckout fetches the files of repo at ref and calls fn with them and
+// the commit they came from. Any checkout directory is removed once fn has
+// returned.
+func (c *Client) withCheckout(ctx context.Context, repo string, ref Ref, fn func(fsys fs.FS, commit string) error) (err error) {
+	var dir string
+	if !c.inMemory {
+		if dir, err = c.checkoutDir(repo); err != nil {
+			return err
+		}
+
+		// clean up once we are done, even if something failed
+		defer func() {
+			if cerr := os.RemoveAll(dir); cerr != nil && err == nil {
+				err = cerr
+			}
+		}()
+	}
+
+	// clone the repo, check it out from the cache or download its archive
+	var fetch fetchFunc = c.clone
+	switch {
+	case c.archives != nil:
+		fetch = c.downloadArchive
+	case c.cache != nil && !c.inMemory:
+		fetch = c.checkoutCached
+	}
+	fsys, commit, err := fetch(ctx, repo, ref, dir)
+	if err != nil {
+		// report the cancellation rather than whatever it broke inside go-git
+		if cerr := ctx.Err(); cerr != nil {
+			return cerr
+		}
+		return err
+	}
+
+	return fn(fsys, commit)
+}
+
+// fetchFunc retrieves the files of repo at ref into dir, or into memory in
+// memory mode, and returns them along with the commit they came from. It is
+// implemented by clone, checkoutCached and downloadArchive.
+type fetchFunc func(ctx context.Context, repo string, ref Ref, dir string) (fs.FS, string, error)
+
+// loader returns the loader used to parse this client's data files.
+func (c *Client) loader() loader {
+	return loader{
+		logger:    c.logger,
+		workers:   c.workers,
+		progress:  c.progress,
+		discovery: c.discovery,
+		strict:    c.strict,
+	}
+}
+
+// checkoutDir creates a fresh directory for a single checkout of repo.
+func (c *Client) checkoutDir(repo string) (string, error) {
+	if err := os.MkdirAll(c.workDir, 0755); err != nil {
+		return "", err
+	}
+
+	return ioutil.TempDir(c.workDir, repoKey(repo)+"-")
+}
+
+// clone fetches only ref from repo into dir, checks it out and returns the
+// resulting worktree and commit hash. In memory mode dir is unused.
+func (c *Client) clone(ctx context.Context, repo string, ref Ref, dir string) (fs.FS, string, error) {
+	url := c.remoteURL(repo)
+	c.logger.Info("cloning repository", "repo", repo, "ref", ref.String(), "url", url)
+
+	var r *git.Repository
+	var fsys fs.FS
+	var err error
+	if c.inMemory {
+		wt := memfs.New()
+		r, err = git.Init(memory.NewStorage(), wt)
+		fsys = billyFS{wt}
+	} else {
+		r, err = git.PlainInit(dir, false)
+		fsys = os.DirFS(dir)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	_, err = r.CreateRemote(&config.RemoteConfig{
+		Name: git.DefaultRemoteName,
+		URLs: []string{url},
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := c.fetchRef(ctx, r, repo, url, ref); err != nil {
+		return nil, "", err
+	}
+
+	commit, err := resolveRef(r, repo, ref)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, "", err
+	}
+
+	// get the worktree
+	w, err := r.Worktree()
+	if err != nil {
+		return nil, "", err
+	}
+
+	// checkout the commit
+	err = w.Checkout(&git.CheckoutOptions{
+		Hash:  commit.Hash,
+		Force: true,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	c.logger.Info("checked out", "repo", repo, "ref", ref.String(), "commit", commit.Hash.String())
+
+	return fsys, commit.Hash.String(), nil
+}
+
+// fetchRef makes a shallow fetch of exactly ref into r. Servers that do not
+// allow fetching arbitrary commits get a full fetch of their branches
+// instead.
+func (c *Client) fetchRef(ctx context.Context, r *git.Repository, repo, url string, ref Ref) error {
+	auth, err := c.authMethod(url)
+	if err != nil {
+		return err
+	}
+
+	opts := &git.FetchOptions{
+		RefSpecs: ref.refSpecs(),
+		Auth:     auth,
+		Progress: c.progress.sideband(),
+		Depth:    1,
+		Tags:     git.NoTags,
+	}
+
+	err = r.FetchContext(ctx, opts)
+	if errors.Is(err, git.ErrExactSHA1NotSupported) {
+		c.logger.Info("remote does not serve single commits, fetching all branches", "repo", repo, "url", url)
+		opts.RefSpecs = []config.RefSpec{config.RefSpec(fmt.Sprintf(config.DefaultFetchRefSpec, git.DefaultRemoteName))}
+		opts.Depth = 0
+		err = r.FetchContext(ctx, opts)
+	}
+
+	switch {
+	case errors.Is(err, git.NoMatchingRefSpecError{}):
+		return ref.notFound(repo)
+	case err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate):
+		return cloneErr(err, repo)
+	}
+
+	return nil
+}
+
+// cloneErr maps go-git's remote errors onto this package's errors.
+func cloneErr(err error, repo string) error {
+	if errors.Is(err, transport.ErrRepositoryNotFound) || errors.Is(err, transport.ErrAuthenticationRequired) {
+		return fmt.Errorf("%w: %s", ErrRepoNotFound, repo)
+	}
+
+	return err
+}
@@ -0,0 +1,242 @@
+package bsdata_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/myminicommission/go-bsdata"
+)
+
+func TestClientGetData(t *testing.T) {
+	base := newTestRemote(t, "test-repo", "1.0.0")
+	client := bsdata.NewClient(
+		bsdata.WithBaseURL("file://"+base),
+		bsdata.WithWorkDir(t.TempDir()),
+	)
+
+	catalogues, err := client.GetData("test-repo", "1.0.0")
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if len(catalogues) != 2 {
+		t.Errorf("expected 2 catalogues, found %d", len(catalogues))
+	}
+}
+
+func TestClientGetDataTagNotFound(t *testing.T) {
+	base := newTestRemote(t, "test-repo", "1.0.0")
+	workDir := t.TempDir()
+	client := bsdata.NewClient(
+		bsdata.WithBaseURL("file://"+base),
+		bsdata.WithWorkDir(workDir),
+	)
+
+	_, err := client.GetData("test-repo", "9.9.9")
+	if !errors.Is(err, bsdata.ErrTagNotFound) {
+		t.Errorf("expected ErrTagNotFound, got %v", err)
+	}
+
+	entries, err := os.ReadDir(workDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 0 {
+		t.Errorf("expected the checkout to be cleaned up, found %d entries", len(entries))
+	}
+}
+
+func TestClientConcurrentGetData(t *testing.T) {
+	base := newTestRemote(t, "test-repo", "1.0.0", "1.0.1")
+	client := bsdata.NewClient(
+		bsdata.WithBaseURL("file://"+base),
+		bsdata.WithWorkDir(t.TempDir()),
+	)
+
+	tags := []string{"1.0.0", "1.0.1", "1.0.0", "1.0.1", "1.0.0", "1.0.1"}
+	errs := make(chan error, len(tags))
+	var wg sync.WaitGroup
+	for _, tag := range tags {
+		wg.Add(1)
+		go func(tag string) {
+			defer wg.Done()
+
+			catalogues, err := client.GetData("test-repo", tag)
+			if err == nil && len(catalogues) != 2 {
+				err = fmt.Errorf("expected 2 catalogues at %s, found %d", tag, len(catalogues))
+			}
+			errs <- err
+		}(tag)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestClientHTTPTransport(t *testing.T) {
+	var requested []string
+	rt := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		requested = append(requested, r.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+			Request:    r,
+		}, nil
+	})
+
+	client := bsdata.NewClient(
+		bsdata.WithBaseURL("https://mirror.invalid/BSData"),
+		bsdata.WithWorkDir(t.TempDir()),
+		bsdata.WithHTTPTransport(rt),
+	)
+
+	_, err := client.GetData("wh40k", "")
+	if !errors.Is(err, bsdata.ErrRepoNotFound) {
+		t.Errorf("expected ErrRepoNotFound, got %v", err)
+	}
+
+	if len(requested) == 0 || !strings.HasPrefix(requested[0], "https://mirror.invalid/BSData/wh40k") {
+		t.Errorf("expected the custom transport to reach the mirror, got %v", requested)
+	}
+}
+
+// newTestRemote creates a git repository named repo under a temporary base
+// directory holding the testdata/local fixtures, tagged with each of tags.
+// It returns the base directory.
+func newTestRemote(t *testing.T, repo string, tags ...string) string {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is required to serve file:// remotes")
+	}
+
+	base := t.TempDir()
+	addTestRepo(t, base, repo, tags...)
+
+	return base
+}
+
+// addTestRepo creates a git repository named repo under base holding the
+// testdata/local fixtures, tagged with each of tags.
+func addTestRepo(t *testing.T, base, repo string, tags ...string) {
+	t.Helper()
+
+	r, err := git.PlainInit(filepath.Join(base, repo), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := os.ReadDir(filepath.Join("testdata", "local"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string][]byte{}
+	for _, entry := range entries {
+		b, err := os.ReadFile(filepath.Join("testdata", "local", entry.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		files[entry.Name()] = b
+	}
+
+	commitTestFiles(t, r, files, tags...)
+}
+
+// commitTestFiles writes files into r's worktree, commits them and tags the
+// commit with each of tags.
+func commitTestFiles(t *testing.T, r *git.Repository, files map[string][]byte, tags ...string) {
+	t.Helper()
+
+	w, err := r.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, b := range files {
+		path := filepath.Join(w.Filesystem.Root(), name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, b, 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := w.Add(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	hash, err := w.Commit("update data", &git.CommitOptions{
+		Author: &object.Signature{Name: "go-bsdata", Email: "go-bsdata@example.com", When: time.Now()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tag := range tags {
+		if _, err := r.CreateTag(tag, hash, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestClientInMemory(t *testing.T) {
+	base := newTestRemote(t, "test-repo", "1.0.0")
+	workDir := filepath.Join(t.TempDir(), "never-created")
+
+	onDisk, err := bsdata.NewClient(
+		bsdata.WithBaseURL("file://"+base),
+		bsdata.WithWorkDir(t.TempDir()),
+	).GetData("test-repo", "1.0.0")
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	inMemory, err := bsdata.NewClient(
+		bsdata.WithBaseURL("file://"+base),
+		bsdata.WithWorkDir(workDir),
+		bsdata.WithInMemory(),
+	).GetData("test-repo", "1.0.0")
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if !reflect.DeepEqual(onDisk, inMemory) {
+		t.Error("expected in-memory catalogues to match on-disk catalogues")
+	}
+
+	if _, err := os.Stat(workDir); !os.IsNotExist(err) {
+		t.Error("expected nothing to be written to the work directory")
+	}
+}
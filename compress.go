@@ -0,0 +1,53 @@
+package bsdata
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+)
+
+// decompress returns the XML held in a data file. The compressed .catz and
+// .gstz forms are zip archives around a single .cat or .gst file; anything
+// else is returned unchanged.
+func decompress(b []byte) ([]byte, error) {
+	if !isZip(b) {
+		return b, nil
+	}
+
+	return unzipSingle(b, "")
+}
+
+// isZip reports whether b starts with a zip local file header.
+func isZip(b []byte) bool {
+	return bytes.HasPrefix(b, []byte("PK\x03\x04"))
+}
+
+// unzipSingle returns the contents of the file called name in the zip
+// archive b, or of its only file if name is empty.
+func unzipSingle(b []byte, name string) ([]byte, error) {
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || (name != "" && f.Name != name) {
+			continue
+		}
+
+		r, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+
+		return ioutil.ReadAll(r)
+	}
+
+	if name == "" {
+		return nil, fmt.Errorf("bsdata: empty zip archive")
+	}
+
+	return nil, fmt.Errorf("bsdata: %s not found in zip archive", name)
+}
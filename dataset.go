@@ -0,0 +1,94 @@
+package bsdata
+
+import "time"
+
+// Dataset is the Battlescribe data of a repository at one ref, together
+// with where and when it was loaded from.
+type Dataset struct {
+	// Repo and Ref are the repository and ref that were requested.
+	Repo string
+	Ref  Ref
+	// Commit is the hash of the commit Ref resolved to. It is empty when
+	// the source does not record one.
+	Commit string
+	// FetchedAt is when the data was fetched.
+	FetchedAt time.Time
+	// GameSystem is the game system the catalogues are written against, or
+	// nil if there was none.
+	GameSystem *GameSystem
+	Catalogues []*Catalogue
+}
+
+// Source identifies the file a catalogue or game system was read from.
+type Source struct {
+	// Path is the slash-separated path of the file within its repository.
+	// It is empty for data parsed from a bare io.Reader.
+	Path string
+	// SHA256 is the hex encoded SHA-256 of the file as stored, before any
+	// decompression.
+	SHA256 string
+}
+
+// linkGameSystem points every catalogue written against ds.GameSystem at
+// it, and logs the catalogues written against another game system.
+func (ds *Dataset) linkGameSystem(logger Logger) {
+	for _, cat := range ds.Catalogues {
+		if ds.GameSystem != nil && cat.GameSystemId == ds.GameSystem.ID {
+			cat.GameSystem = ds.GameSystem
+			continue
+		}
+
+		logger.Info("catalogue has no matching game system", "file", cat.Source.Path, "gameSystemId", cat.GameSystemId)
+	}
+}
+
+// resolveImports points every catalogue's Imports at the catalogues its
+// links target and gathers the root entries it imports. It returns a
+// *MissingImportError for each link whose target is not in ds.
+func (ds *Dataset) resolveImports() []error {
+	byID := map[string]*Catalogue{}
+	for _, cat := range ds.Catalogues {
+		byID[cat.ID] = cat
+	}
+
+	var errs []error
+	for _, cat := range ds.Catalogues {
+		for _, link := range cat.CatalogueLinks.CatalogueLink {
+			target, ok := byID[link.TargetId]
+			if !ok {
+				errs = append(errs, &MissingImportError{
+					File:      cat.Source.Path,
+					Catalogue: cat.ID,
+					TargetId:  link.TargetId,
+					Name:      link.Name,
+				})
+				continue
+			}
+
+			cat.Imports = append(cat.Imports, target)
+		}
+	}
+
+	for _, cat := range ds.Catalogues {
+		cat.importRootEntries(cat, byID, map[*Catalogue]bool{cat: true})
+	}
+
+	return errs
+}
+
+// importRootEntries adds the root entries of the catalogues that from
+// imports with importRootEntries to c, following their own imports. seen
+// guards against import cycles.
+func (c *Catalogue) importRootEntries(from *Catalogue, byID map[string]*Catalogue, seen map[*Catalogue]bool) {
+	for _, link := range from.CatalogueLinks.CatalogueLink {
+		target, ok := byID[link.TargetId]
+		if !ok || !bool(link.ImportRootEntries) || seen[target] {
+			continue
+		}
+		seen[target] = true
+
+		c.ImportedEntryLinks = append(c.ImportedEntryLinks, target.EntryLinks.EntryLink...)
+		c.ImportedSelectionEntries = append(c.ImportedSelectionEntries, target.SelectionEntries.SelectionEntry...)
+		c.importRootEntries(target, byID, seen)
+	}
+}
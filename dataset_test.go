@@ -0,0 +1,53 @@
+package bsdata_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/myminicommission/go-bsdata"
+)
+
+func TestClientGetDataset(t *testing.T) {
+	base := newTestRemote(t, "test-repo", "1.0.0")
+	client := bsdata.NewClient(
+		bsdata.WithBaseURL("file://"+base),
+		bsdata.WithWorkDir(t.TempDir()),
+	)
+
+	before := time.Now()
+	ds, err := client.GetDataset(context.Background(), "test-repo", bsdata.Tag("1.0.0"))
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if ds.Repo != "test-repo" || ds.Ref != bsdata.Tag("1.0.0") {
+		t.Errorf("unexpected repo and ref %q %v", ds.Repo, ds.Ref)
+	}
+
+	if len(ds.Commit) != 40 {
+		t.Errorf("expected a commit hash, got %q", ds.Commit)
+	}
+
+	if ds.FetchedAt.Before(before) || ds.FetchedAt.After(time.Now()) {
+		t.Errorf("unexpected fetch time %v", ds.FetchedAt)
+	}
+
+	if ds.GameSystem == nil || ds.GameSystem.ID != "gst-1" {
+		t.Errorf("expected game system gst-1, got %+v", ds.GameSystem)
+	}
+
+	if len(ds.Catalogues) != 2 {
+		t.Errorf("expected 2 catalogues, found %d", len(ds.Catalogues))
+		t.FailNow()
+	}
+
+	sum := sha256.Sum256(readFixture(t, "Test-Faction.cat"))
+	source := bsdata.Source{Path: "Test-Faction.cat", SHA256: hex.EncodeToString(sum[:])}
+	if ds.Catalogues[0].Source != source {
+		t.Errorf("expected source %+v, got %+v", source, ds.Catalogues[0].Source)
+	}
+}
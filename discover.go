@@ -0,0 +1,154 @@
+package bsdata
+
+import (
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// FileType is the kind of a Battlescribe data file, taken from its
+// extension.
+type FileType string
+
+const (
+	// FileCat is a catalogue.
+	FileCat FileType = "cat"
+	// FileGst is a game system.
+	FileGst FileType = "gst"
+	// FileCatz is a zipped catalogue.
+	FileCatz FileType = "catz"
+	// FileGstz is a zipped game system.
+	FileGstz FileType = "gstz"
+)
+
+// fileTypes maps data file extensions to their types.
+var fileTypes = map[string]FileType{
+	".cat":  FileCat,
+	".gst":  FileGst,
+	".catz": FileCatz,
+	".gstz": FileGstz,
+}
+
+// fileType returns the type of the data file name, matching its extension
+// case-insensitively. It returns false for files that are not data files.
+func fileType(name string) (FileType, bool) {
+	t, ok := fileTypes[strings.ToLower(path.Ext(name))]
+	return t, ok
+}
+
+func isDataFile(name string) bool {
+	_, ok := fileType(name)
+	return ok
+}
+
+// isCatalogue reports whether files of type t hold a catalogue.
+func (t FileType) isCatalogue() bool {
+	return t == FileCat || t == FileCatz
+}
+
+// DataFile is a data file found by Discover.
+type DataFile struct {
+	// Path is the slash-separated path of the file within the searched
+	// filesystem.
+	Path string
+	Size int64
+	Type FileType
+}
+
+// DiscoverOptions controls which files Discover returns.
+type DiscoverOptions struct {
+	// Recursive searches subdirectories as well as the root. Hidden
+	// directories such as .git are always skipped.
+	Recursive bool
+	// Include holds path.Match patterns. When it is not empty only files
+	// matching at least one of them are returned.
+	Include []string
+	// Exclude holds path.Match patterns for files to leave out. It takes
+	// precedence over Include.
+	Exclude []string
+}
+
+// WithDiscovery sets how the client finds the data files in a checkout. By
+// default only the root of the checkout is searched.
+func WithDiscovery(opts DiscoverOptions) Option {
+	return func(c *Client) {
+		c.discovery = opts
+	}
+}
+
+// Discover lists the data files in fsys without parsing them. Files are
+// recognised by their .cat, .gst, .catz or .gstz extension and returned in
+// lexical path order. Patterns are matched against both the file's path and
+// its base name, so "*Legends*" and "Legacy/*" both work.
+func Discover(fsys fs.FS, opts DiscoverOptions) ([]DataFile, error) {
+	return discover(fsys, opts, nopLogger{})
+}
+
+func discover(fsys fs.FS, opts DiscoverOptions, logger Logger) ([]DataFile, error) {
+	// report bad patterns up front rather than only when a file is reached
+	for _, pattern := range append(append([]string(nil), opts.Include...), opts.Exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	var files []DataFile
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if name == "." {
+				return nil
+			}
+			if !opts.Recursive || strings.HasPrefix(d.Name(), ".") {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		t, ok := fileType(name)
+		ok = ok && opts.selects(name)
+		logger.Debug("inspected file", "file", name, "data", ok)
+		if !ok {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		files = append(files, DataFile{Path: name, Size: info.Size(), Type: t})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// selects reports whether the include and exclude patterns let name through.
+func (opts DiscoverOptions) selects(name string) bool {
+	if matchAny(opts.Exclude, name) {
+		return false
+	}
+
+	return len(opts.Include) == 0 || matchAny(opts.Include, name)
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		// the patterns were validated by discover
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, path.Base(name)); ok {
+			return true
+		}
+	}
+
+	return false
+}
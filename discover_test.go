@@ -0,0 +1,120 @@
+package bsdata_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/myminicommission/go-bsdata"
+)
+
+func discoverFixture() fstest.MapFS {
+	return fstest.MapFS{
+		"Faction.cat":             &fstest.MapFile{Data: []byte("cat")},
+		"System.gst":              &fstest.MapFile{Data: []byte("gst")},
+		"Faction.cat.bak":         &fstest.MapFile{Data: []byte("backup")},
+		"catalogue-notes.txt":     &fstest.MapFile{Data: []byte("notes")},
+		"Legends/Old.CATZ":        &fstest.MapFile{Data: []byte("zipped")},
+		"Legends/Deep/Older.gstz": &fstest.MapFile{Data: []byte("zip")},
+		".git/objects/x.cat":      &fstest.MapFile{Data: []byte("git")},
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	files, err := bsdata.Discover(discoverFixture(), bsdata.DiscoverOptions{})
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	expected := []bsdata.DataFile{
+		{Path: "Faction.cat", Size: 3, Type: bsdata.FileCat},
+		{Path: "System.gst", Size: 3, Type: bsdata.FileGst},
+	}
+	if len(files) != len(expected) {
+		t.Errorf("expected %v, got %v", expected, files)
+		t.FailNow()
+	}
+	for i := range expected {
+		if files[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected[i], files[i])
+		}
+	}
+}
+
+func TestDiscoverRecursive(t *testing.T) {
+	files, err := bsdata.Discover(discoverFixture(), bsdata.DiscoverOptions{Recursive: true})
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	var paths []string
+	for _, f := range files {
+		paths = append(paths, f.Path)
+	}
+
+	expected := []string{"Faction.cat", "Legends/Deep/Older.gstz", "Legends/Old.CATZ", "System.gst"}
+	if len(paths) != len(expected) {
+		t.Errorf("expected %v, got %v", expected, paths)
+		t.FailNow()
+	}
+	for i := range expected {
+		if paths[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected, paths)
+			break
+		}
+	}
+
+	if files[2].Type != bsdata.FileCatz {
+		t.Errorf("expected catz, got %s", files[2].Type)
+	}
+}
+
+func TestDiscoverPatterns(t *testing.T) {
+	opts := bsdata.DiscoverOptions{
+		Recursive: true,
+		Include:   []string{"*.cat", "Legends/*"},
+		Exclude:   []string{"Faction*"},
+	}
+
+	files, err := bsdata.Discover(discoverFixture(), opts)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if len(files) != 1 || files[0].Path != "Legends/Old.CATZ" {
+		t.Errorf("expected only Legends/Old.CATZ, got %v", files)
+	}
+}
+
+func TestDiscoverBadPattern(t *testing.T) {
+	_, err := bsdata.Discover(discoverFixture(), bsdata.DiscoverOptions{Include: []string{"["}})
+	if err == nil {
+		t.Error("expected an error for a malformed pattern")
+	}
+}
+
+func TestClientLoadFSDiscovery(t *testing.T) {
+	fsys := fstest.MapFS{
+		"Test-Faction.cat":          &fstest.MapFile{Data: readFixture(t, "Test-Faction.cat")},
+		"Test-Faction.cat.bak":      &fstest.MapFile{Data: []byte("<catalogue")},
+		"Library/Test-Library.cat":  &fstest.MapFile{Data: readFixture(t, "Test-Library.cat")},
+		"Archived/Test-Library.cat": &fstest.MapFile{Data: []byte("<catalogue")},
+	}
+
+	client := bsdata.NewClient(bsdata.WithDiscovery(bsdata.DiscoverOptions{
+		Recursive: true,
+		Exclude:   []string{"Archived/*"},
+	}))
+
+	catalogues, err := client.LoadFS(fsys)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if len(catalogues) != 2 {
+		t.Errorf("expected 2 catalogues, found %d", len(catalogues))
+	}
+}
@@ -0,0 +1,187 @@
+package bsdata
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Bool is a boolean attribute such as hidden or collective.
+type Bool bool
+
+func (b *Bool) UnmarshalXMLAttr(attr xml.Attr) error {
+	v, err := strconv.ParseBool(strings.TrimSpace(attr.Value))
+	if err != nil {
+		return fmt.Errorf("bsdata: invalid boolean %q in attribute %s, expected true or false",
+			attr.Value, attr.Name.Local)
+	}
+
+	*b = Bool(v)
+	return nil
+}
+
+// Float is a numeric attribute such as the value of a cost or constraint.
+type Float float64
+
+func (f *Float) UnmarshalXMLAttr(attr xml.Attr) error {
+	v, err := strconv.ParseFloat(strings.TrimSpace(attr.Value), 64)
+	if err != nil {
+		return fmt.Errorf("bsdata: invalid number %q in attribute %s", attr.Value, attr.Name.Local)
+	}
+
+	*f = Float(v)
+	return nil
+}
+
+// Int is a whole number attribute such as the repeats of a Repeat.
+type Int int
+
+func (i *Int) UnmarshalXMLAttr(attr xml.Attr) error {
+	v, err := strconv.Atoi(strings.TrimSpace(attr.Value))
+	if err != nil {
+		return fmt.Errorf("bsdata: invalid whole number %q in attribute %s", attr.Value, attr.Name.Local)
+	}
+
+	*i = Int(v)
+	return nil
+}
+
+// ModifierType is how a Modifier changes its field.
+type ModifierType string
+
+const (
+	ModifierSet          ModifierType = "set"
+	ModifierIncrement    ModifierType = "increment"
+	ModifierDecrement    ModifierType = "decrement"
+	ModifierAppend       ModifierType = "append"
+	ModifierAdd          ModifierType = "add"
+	ModifierRemove       ModifierType = "remove"
+	ModifierSetPrimary   ModifierType = "set-primary"
+	ModifierUnsetPrimary ModifierType = "unset-primary"
+)
+
+func (t *ModifierType) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalEnum(attr, (*string)(t), "modifier type",
+		ModifierSet, ModifierIncrement, ModifierDecrement, ModifierAppend,
+		ModifierAdd, ModifierRemove, ModifierSetPrimary, ModifierUnsetPrimary)
+}
+
+// ConditionType is the comparison a Condition makes.
+type ConditionType string
+
+const (
+	ConditionLessThan      ConditionType = "lessThan"
+	ConditionGreaterThan   ConditionType = "greaterThan"
+	ConditionEqualTo       ConditionType = "equalTo"
+	ConditionNotEqualTo    ConditionType = "notEqualTo"
+	ConditionAtLeast       ConditionType = "atLeast"
+	ConditionAtMost        ConditionType = "atMost"
+	ConditionInstanceOf    ConditionType = "instanceOf"
+	ConditionNotInstanceOf ConditionType = "notInstanceOf"
+)
+
+func (t *ConditionType) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalEnum(attr, (*string)(t), "condition type",
+		ConditionLessThan, ConditionGreaterThan, ConditionEqualTo, ConditionNotEqualTo,
+		ConditionAtLeast, ConditionAtMost, ConditionInstanceOf, ConditionNotInstanceOf)
+}
+
+// ConditionGroupType is how a ConditionGroup combines its conditions.
+type ConditionGroupType string
+
+const (
+	ConditionGroupAnd ConditionGroupType = "and"
+	ConditionGroupOr  ConditionGroupType = "or"
+)
+
+func (t *ConditionGroupType) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalEnum(attr, (*string)(t), "condition group type", ConditionGroupAnd, ConditionGroupOr)
+}
+
+// ConstraintType is whether a Constraint sets a minimum or a maximum.
+type ConstraintType string
+
+const (
+	ConstraintMin ConstraintType = "min"
+	ConstraintMax ConstraintType = "max"
+)
+
+func (t *ConstraintType) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalEnum(attr, (*string)(t), "constraint type", ConstraintMin, ConstraintMax)
+}
+
+// SelectionEntryType is the kind of thing a SelectionEntry is.
+type SelectionEntryType string
+
+const (
+	SelectionEntryUnit    SelectionEntryType = "unit"
+	SelectionEntryModel   SelectionEntryType = "model"
+	SelectionEntryUpgrade SelectionEntryType = "upgrade"
+)
+
+func (t *SelectionEntryType) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalEnum(attr, (*string)(t), "selection entry type",
+		SelectionEntryUnit, SelectionEntryModel, SelectionEntryUpgrade)
+}
+
+// EntryLinkType is the kind of element an EntryLink targets.
+type EntryLinkType string
+
+const (
+	EntryLinkSelectionEntry      EntryLinkType = "selectionEntry"
+	EntryLinkSelectionEntryGroup EntryLinkType = "selectionEntryGroup"
+)
+
+func (t *EntryLinkType) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalEnum(attr, (*string)(t), "entry link type",
+		EntryLinkSelectionEntry, EntryLinkSelectionEntryGroup)
+}
+
+// Scope is where a Condition, Constraint or Repeat counts selections. It
+// is either one of the keywords below or the ID of a selection entry,
+// force entry or category, so unlike the other enums any value is
+// accepted.
+type Scope string
+
+const (
+	ScopeSelf             Scope = "self"
+	ScopeParent           Scope = "parent"
+	ScopeAncestor         Scope = "ancestor"
+	ScopeForce            Scope = "force"
+	ScopeRoster           Scope = "roster"
+	ScopePrimaryCategory  Scope = "primary-category"
+	ScopePrimaryCatalogue Scope = "primary-catalogue"
+)
+
+var scopeKeywords = map[Scope]bool{
+	ScopeSelf:             true,
+	ScopeParent:           true,
+	ScopeAncestor:         true,
+	ScopeForce:            true,
+	ScopeRoster:           true,
+	ScopePrimaryCategory:  true,
+	ScopePrimaryCatalogue: true,
+}
+
+// IsID reports whether s is the ID of an entry or category rather than one
+// of the scope keywords.
+func (s Scope) IsID() bool {
+	return s != "" && !scopeKeywords[s]
+}
+
+// unmarshalEnum stores the value of attr in v if it is one of valid, and
+// otherwise returns an error naming the attribute and the values allowed.
+func unmarshalEnum(attr xml.Attr, v *string, kind string, valid ...interface{}) error {
+	names := make([]string, len(valid))
+	for i, val := range valid {
+		names[i] = fmt.Sprint(val)
+		if names[i] == attr.Value {
+			*v = attr.Value
+			return nil
+		}
+	}
+
+	return fmt.Errorf("bsdata: unknown %s %q in attribute %s, expected one of %s",
+		kind, attr.Value, attr.Name.Local, strings.Join(names, ", "))
+}
@@ -0,0 +1,117 @@
+package bsdata_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/myminicommission/go-bsdata"
+)
+
+func TestTypedAttributes(t *testing.T) {
+	cat, err := bsdata.ParseCatalogue(bytes.NewReader(readFixture(t, "Test-Faction.cat")))
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	entry := cat.SharedSelectionEntries.SelectionEntry[0]
+	if entry.Hidden || !entry.Import || entry.Type != bsdata.SelectionEntryUnit {
+		t.Errorf("unexpected entry attributes %v %v %q", entry.Hidden, entry.Import, entry.Type)
+	}
+
+	if entry.Costs.Cost[0].Value != 10 {
+		t.Errorf("expected a cost of 10, got %v", entry.Costs.Cost[0].Value)
+	}
+
+	cat, err = bsdata.ParseCatalogue(strings.NewReader(nestedCatalogue))
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	modifier := cat.SharedSelectionEntries.SelectionEntry[0].Modifiers.Modifier[0]
+	if modifier.Type != bsdata.ModifierIncrement {
+		t.Errorf("expected an increment modifier, got %q", modifier.Type)
+	}
+
+	if v, err := modifier.FloatValue(); err != nil || v != 1 {
+		t.Errorf("expected a modifier value of 1, got %v %v", v, err)
+	}
+
+	condition := modifier.Conditions.Condition[0]
+	if condition.Type != bsdata.ConditionAtLeast || condition.Scope != bsdata.ScopeRoster || condition.Value != 1 {
+		t.Errorf("unexpected condition %+v", condition)
+	}
+
+	if modifier.ConditionGroups.ConditionGroup[0].Type != bsdata.ConditionGroupOr {
+		t.Errorf("expected an or condition group, got %q", modifier.ConditionGroups.ConditionGroup[0].Type)
+	}
+}
+
+func TestUnknownEnumValue(t *testing.T) {
+	xml := `<catalogue id="c">
+  <sharedSelectionEntries>
+    <selectionEntry id="e" name="E" type="vehicle"/>
+  </sharedSelectionEntries>
+</catalogue>`
+
+	_, err := bsdata.ParseCatalogue(strings.NewReader(xml))
+	var perr *bsdata.ParseError
+	if !errors.As(err, &perr) {
+		t.Errorf("expected a ParseError, got %v", err)
+		t.FailNow()
+	}
+
+	if perr.Line != 3 || !strings.Contains(err.Error(), `unknown selection entry type "vehicle"`) {
+		t.Errorf("unexpected error at line %d: %v", perr.Line, err)
+	}
+}
+
+func TestInvalidAttributeValues(t *testing.T) {
+	tests := []struct {
+		xml  string
+		want string
+	}{
+		{
+			xml:  `<catalogue id="c" library="maybe"/>`,
+			want: `invalid boolean "maybe" in attribute library`,
+		},
+		{
+			xml: `<catalogue id="c"><selectionEntries>
+  <selectionEntry id="e" hidden="nope"/>
+</selectionEntries></catalogue>`,
+			want: `invalid boolean "nope" in attribute hidden`,
+		},
+		{
+			xml: `<catalogue id="c"><selectionEntries><selectionEntry id="e"><costs>
+  <cost name="pts" typeId="pts" value="ten"/>
+</costs></selectionEntry></selectionEntries></catalogue>`,
+			want: `invalid number "ten" in attribute value`,
+		},
+		{
+			xml: `<catalogue id="c"><selectionEntries><selectionEntry id="e"><modifiers><modifier type="set" field="f" value="1"><repeats>
+  <repeat field="selections" scope="parent" value="1" childId="x" repeats="1.5"/>
+</repeats></modifier></modifiers></selectionEntry></selectionEntries></catalogue>`,
+			want: `invalid whole number "1.5" in attribute repeats`,
+		},
+	}
+
+	for _, tt := range tests {
+		_, err := bsdata.ParseCatalogue(strings.NewReader(tt.xml))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("expected an error containing %q, got %v", tt.want, err)
+		}
+	}
+}
+
+func TestScopeIsID(t *testing.T) {
+	if bsdata.ScopeForce.IsID() {
+		t.Error("expected force to be a keyword")
+	}
+
+	if !bsdata.Scope("a1b2-c3d4").IsID() {
+		t.Error("expected an entry ID scope")
+	}
+}
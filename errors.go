@@ -0,0 +1,149 @@
+package bsdata
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrRepoNotFound is returned when the requested data repository does not
+	// exist or cannot be accessed.
+	ErrRepoNotFound = errors.New("bsdata: repository not found")
+
+	// ErrTagNotFound is returned when the requested tag does not exist in the
+	// data repository.
+	ErrTagNotFound = errors.New("bsdata: tag not found")
+
+	// ErrRefNotFound is returned when the requested branch or commit does
+	// not exist in the data repository.
+	ErrRefNotFound = errors.New("bsdata: ref not found")
+
+	// ErrTransportReplaced is returned when a client with its own HTTP
+	// transport or progress reporting talks to an HTTP(S) remote after
+	// go-git's http or https protocol was replaced with
+	// client.InstallProtocol, so the client's round tripper can no longer
+	// be used.
+	ErrTransportReplaced = errors.New("bsdata: go-git http transport was replaced")
+)
+
+// ParseError describes a data file that could not be decoded.
+type ParseError struct {
+	// File is the name of the file being parsed, if known.
+	File string
+	// Line is the 1-based line at which decoding stopped.
+	Line int
+	// Offset is the byte offset at which decoding stopped.
+	Offset int64
+	// Err is the underlying decoding error.
+	Err error
+}
+
+func (e *ParseError) Error() string {
+	file := e.File
+	if file == "" {
+		file = "<input>"
+	}
+
+	return fmt.Sprintf("bsdata: parsing %s at line %d (offset %d): %v", file, e.Line, e.Offset, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// HTTPError is returned when a download gets a response other than 200 OK.
+type HTTPError struct {
+	URL        string
+	StatusCode int
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("bsdata: downloading %s: %d %s", e.URL, e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// LoadErrors holds one error for each data file that could not be loaded
+// and for each catalogue link whose target was not loaded.
+type LoadErrors []error
+
+func (e LoadErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+
+	return fmt.Sprintf("bsdata: %d data files or imports failed to load:\n%s", len(e), strings.Join(msgs, "\n"))
+}
+
+// Is reports whether any of the individual errors matches target, so that
+// errors.Is looks inside a LoadErrors. The module supports Go versions
+// without multi-error unwrapping, hence the explicit loop.
+func (e LoadErrors) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// As finds the first of the individual errors that matches target, so that
+// errors.As looks inside a LoadErrors.
+func (e LoadErrors) As(target interface{}) bool {
+	for _, err := range e {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MissingImportError is reported when a catalogue links to a catalogue
+// that was not loaded with it.
+type MissingImportError struct {
+	// File is the name of the importing catalogue's file, if known.
+	File string
+	// Catalogue is the ID of the importing catalogue.
+	Catalogue string
+	// TargetId and Name identify the missing catalogue as the link does.
+	TargetId string
+	Name     string
+}
+
+func (e *MissingImportError) Error() string {
+	from := e.File
+	if from == "" {
+		from = e.Catalogue
+	}
+
+	return fmt.Sprintf("bsdata: %s imports catalogue %s (%s), which was not loaded", from, e.TargetId, e.Name)
+}
+
+// StrictError is returned in strict mode for a data file holding XML that
+// the model does not decode into a field.
+type StrictError struct {
+	// File is the name of the file being parsed, if known.
+	File       string
+	Unconsumed []Unconsumed
+}
+
+func (e *StrictError) Error() string {
+	file := e.File
+	if file == "" {
+		file = "<input>"
+	}
+
+	msgs := make([]string, len(e.Unconsumed))
+	for i, u := range e.Unconsumed {
+		msgs[i] = u.String()
+	}
+
+	return fmt.Sprintf("bsdata: %s has %d unconsumed XML items:\n%s", file, len(e.Unconsumed), strings.Join(msgs, "\n"))
+}
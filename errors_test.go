@@ -0,0 +1,79 @@
+package bsdata_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/myminicommission/go-bsdata"
+)
+
+func TestParseErrorLocation(t *testing.T) {
+	input := "<catalogue id=\"cat-1\">\n<publications>\n</catalogue>"
+	_, err := bsdata.ParseCatalogue(strings.NewReader(input))
+
+	var perr *bsdata.ParseError
+	if !errors.As(err, &perr) {
+		t.Errorf("expected a *ParseError, got %v", err)
+		t.FailNow()
+	}
+
+	if perr.Line != 3 {
+		t.Errorf("expected error on line 3, got %d", perr.Line)
+	}
+
+	if perr.Offset == 0 {
+		t.Error("expected a non-zero offset")
+	}
+}
+
+func TestParseErrorFile(t *testing.T) {
+	fsys := fstest.MapFS{
+		"Broken.cat": &fstest.MapFile{Data: []byte("<catalogue")},
+	}
+
+	_, err := bsdata.LoadFS(fsys)
+
+	var perr *bsdata.ParseError
+	if !errors.As(err, &perr) {
+		t.Errorf("expected a *ParseError, got %v", err)
+		t.FailNow()
+	}
+
+	if perr.File != "Broken.cat" {
+		t.Errorf("expected file Broken.cat, got %q", perr.File)
+	}
+}
+
+func TestLoadErrorsMatching(t *testing.T) {
+	perr := &bsdata.ParseError{File: "Broken.cat"}
+	errs := bsdata.LoadErrors{errors.New("other"), fmt.Errorf("wrapped: %w", perr)}
+
+	// call the methods directly, as errors.Is and errors.As do on
+	// toolchains without multi-error unwrapping
+	var target *bsdata.ParseError
+	if !errs.As(&target) || target != perr {
+		t.Errorf("expected As to find the ParseError, got %v", target)
+	}
+
+	if !errs.Is(perr) {
+		t.Error("expected Is to match the ParseError")
+	}
+
+	if errs.Is(bsdata.ErrRepoNotFound) {
+		t.Error("expected Is not to match an unrelated error")
+	}
+}
+
+func TestLoadErrorsMessage(t *testing.T) {
+	errs := bsdata.LoadErrors{
+		&bsdata.MissingImportError{File: "Test-Faction.cat", TargetId: "lib-1"},
+		&bsdata.MissingImportError{File: "Test-Library.cat", TargetId: "lib-2"},
+	}
+
+	if msg := errs.Error(); !strings.HasPrefix(msg, "bsdata: 2 data files or imports failed to load") {
+		t.Errorf("unexpected message %q", msg)
+	}
+}
@@ -0,0 +1,93 @@
+package bsdata
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// flightGroup merges concurrent fetches of the same key so the work is done
+// once and every caller receives its result.
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+// flight is a single in-progress fetch. It runs with its own context, which
+// is cancelled once every caller waiting on it has given up.
+type flight struct {
+	done    chan struct{}
+	cancel  context.CancelFunc
+	waiters int
+
+	res *Dataset
+	err error
+}
+
+// do calls fn for key, or waits for the call already in progress for key.
+// It returns with ctx's error if ctx ends before the result is ready. The
+// last caller to give up cancels fn and waits for it to return, so fn has
+// cleaned up by then; earlier callers leave it running for the others.
+// A panic in fn is returned to every caller as an error.
+func (g *flightGroup) do(ctx context.Context, key string, fn func(context.Context) (*Dataset, error)) (*Dataset, error) {
+	g.mu.Lock()
+	if g.flights == nil {
+		g.flights = map[string]*flight{}
+	}
+
+	f, ok := g.flights[key]
+	if !ok {
+		fctx, cancel := context.WithCancel(context.Background())
+		f = &flight{done: make(chan struct{}), cancel: cancel}
+		g.flights[key] = f
+
+		go func() {
+			defer close(f.done)
+			defer func() {
+				if r := recover(); r != nil {
+					f.res, f.err = nil, fmt.Errorf("bsdata: fetch of %s panicked: %v", key, r)
+				}
+
+				cancel()
+
+				g.mu.Lock()
+				g.forget(key, f)
+				g.mu.Unlock()
+			}()
+
+			f.res, f.err = fn(fctx)
+		}()
+	}
+	f.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-f.done:
+		return f.res, f.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		f.waiters--
+		last := f.waiters == 0
+		if last {
+			// nobody wants the result any more, so later callers must
+			// start a fresh fetch instead of joining a cancelled one
+			f.cancel()
+			g.forget(key, f)
+		}
+		g.mu.Unlock()
+
+		if last {
+			<-f.done
+		}
+
+		return nil, ctx.Err()
+	}
+}
+
+// forget removes f from the group if it is still the flight for key. g.mu
+// must be held.
+func (g *flightGroup) forget(key string, f *flight) {
+	if g.flights[key] == f {
+		delete(g.flights, key)
+	}
+}
@@ -0,0 +1,102 @@
+package bsdata
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestFlightGroupMergesCalls(t *testing.T) {
+	var g flightGroup
+	var calls int32
+	release := make(chan struct{})
+	want := &Dataset{Catalogues: []*Catalogue{{Name: "Test Faction"}}}
+
+	const callers = 5
+	var started, wg sync.WaitGroup
+	started.Add(callers)
+	results := make([]*Dataset, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			started.Done()
+
+			results[i], _ = g.do(context.Background(), "repo@tag", func(context.Context) (*Dataset, error) {
+				atomic.AddInt32(&calls, 1)
+				<-release
+				return want, nil
+			})
+		}(i)
+	}
+
+	// let every caller join before the fetch is allowed to finish
+	started.Wait()
+	for {
+		g.mu.Lock()
+		f := g.flights["repo@tag"]
+		joined := f != nil && f.waiters == callers
+		g.mu.Unlock()
+		if joined {
+			break
+		}
+		runtime.Gosched()
+	}
+	close(release)
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("expected 1 call, got %d", calls)
+	}
+
+	for i, got := range results {
+		if got != want {
+			t.Errorf("caller %d got %v", i, got)
+		}
+	}
+}
+
+func TestFlightGroupCancelsAbandonedCalls(t *testing.T) {
+	var g flightGroup
+	cancelled := make(chan struct{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go cancel()
+
+	_, err := g.do(ctx, "repo@tag", func(ctx context.Context) (*Dataset, error) {
+		<-ctx.Done()
+		close(cancelled)
+		return nil, ctx.Err()
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+
+	// the last caller waits for the fetch to wind down
+	select {
+	case <-cancelled:
+	default:
+		t.Error("expected the fetch to have returned")
+	}
+}
+
+func TestFlightGroupRecoversPanics(t *testing.T) {
+	var g flightGroup
+
+	_, err := g.do(context.Background(), "repo@tag", func(context.Context) (*Dataset, error) {
+		panic("boom")
+	})
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("expected the panic as an error, got %v", err)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if len(g.flights) != 0 {
+		t.Error("expected the flight to be forgotten")
+	}
+}
@@ -0,0 +1,180 @@
+package bsdata
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// GameSystem is a Battlescribe game system, read from the .gst or .gstz
+// file that the catalogues of a data repository are written against. It
+// holds the cost types, profile types, categories, force organisation and
+// rules that the catalogues refer to.
+type GameSystem struct {
+	XMLName xml.Name `xml:"gameSystem"`
+	Extra
+	Text                string `xml:",chardata"`
+	ID                  string `xml:"id,attr"`
+	Name                string `xml:"name,attr"`
+	Revision            string `xml:"revision,attr"`
+	BattleScribeVersion string `xml:"battleScribeVersion,attr"`
+	AuthorName          string `xml:"authorName,attr"`
+	AuthorContact       string `xml:"authorContact,attr"`
+	AuthorUrl           string `xml:"authorUrl,attr"`
+	Xmlns               string `xml:"xmlns,attr"`
+	Comment             string `xml:"comment"`
+	Readme              string `xml:"readme"`
+	Publications        struct {
+		Extra
+		Text        string `xml:",chardata"`
+		Publication []struct {
+			Extra
+			Text            string `xml:",chardata"`
+			ID              string `xml:"id,attr"`
+			Name            string `xml:"name,attr"`
+			ShortName       string `xml:"shortName,attr"`
+			Publisher       string `xml:"publisher,attr"`
+			PublicationDate string `xml:"publicationDate,attr"`
+		} `xml:"publication"`
+	} `xml:"publications"`
+	CostTypes struct {
+		Extra
+		Text     string `xml:",chardata"`
+		CostType []struct {
+			Extra
+			Text             string `xml:",chardata"`
+			ID               string `xml:"id,attr"`
+			Name             string `xml:"name,attr"`
+			DefaultCostLimit Float  `xml:"defaultCostLimit,attr"`
+			Hidden           Bool   `xml:"hidden,attr"`
+		} `xml:"costType"`
+	} `xml:"costTypes"`
+	ProfileTypes struct {
+		Extra
+		Text        string `xml:",chardata"`
+		ProfileType []struct {
+			Extra
+			Text                string `xml:",chardata"`
+			ID                  string `xml:"id,attr"`
+			Name                string `xml:"name,attr"`
+			CharacteristicTypes struct {
+				Extra
+				Text               string `xml:",chardata"`
+				CharacteristicType []struct {
+					Extra
+					Text string `xml:",chardata"`
+					ID   string `xml:"id,attr"`
+					Name string `xml:"name,attr"`
+				} `xml:"characteristicType"`
+			} `xml:"characteristicTypes"`
+		} `xml:"profileType"`
+	} `xml:"profileTypes"`
+	CategoryEntries struct {
+		Extra
+		Text          string `xml:",chardata"`
+		CategoryEntry []struct {
+			Extra
+			Text   string `xml:",chardata"`
+			ID     string `xml:"id,attr"`
+			Name   string `xml:"name,attr"`
+			Hidden Bool   `xml:"hidden,attr"`
+		} `xml:"categoryEntry"`
+	} `xml:"categoryEntries"`
+	ForceEntries struct {
+		Extra
+		Text       string       `xml:",chardata"`
+		ForceEntry []ForceEntry `xml:"forceEntry"`
+	} `xml:"forceEntries"`
+	EntryLinks struct {
+		Extra
+		Text      string      `xml:",chardata"`
+		EntryLink []EntryLink `xml:"entryLink"`
+	} `xml:"entryLinks"`
+	SharedSelectionEntries struct {
+		Extra
+		Text           string           `xml:",chardata"`
+		SelectionEntry []SelectionEntry `xml:"selectionEntry"`
+	} `xml:"sharedSelectionEntries"`
+	SharedSelectionEntryGroups struct {
+		Extra
+		Text                string                `xml:",chardata"`
+		SelectionEntryGroup []SelectionEntryGroup `xml:"selectionEntryGroup"`
+	} `xml:"sharedSelectionEntryGroups"`
+	SharedRules struct {
+		Extra
+		Text string `xml:",chardata"`
+		Rule []Rule `xml:"rule"`
+	} `xml:"sharedRules"`
+	SharedProfiles struct {
+		Extra
+		Text    string    `xml:",chardata"`
+		Profile []Profile `xml:"profile"`
+	} `xml:"sharedProfiles"`
+
+	// Source records the file the game system was read from. It is not
+	// part of the XML.
+	Source Source `xml:"-"`
+}
+
+// ForceEntry is a force organisation a roster can include, such as a
+// detachment. Force entries can hold further force entries.
+type ForceEntry struct {
+	Extra
+	Text      string `xml:",chardata"`
+	ID        string `xml:"id,attr"`
+	Name      string `xml:"name,attr"`
+	Hidden    Bool   `xml:"hidden,attr"`
+	Modifiers struct {
+		Extra
+		Text     string     `xml:",chardata"`
+		Modifier []Modifier `xml:"modifier"`
+	} `xml:"modifiers"`
+	ModifierGroups struct {
+		Extra
+		Text          string          `xml:",chardata"`
+		ModifierGroup []ModifierGroup `xml:"modifierGroup"`
+	} `xml:"modifierGroups"`
+	Constraints struct {
+		Extra
+		Text       string       `xml:",chardata"`
+		Constraint []Constraint `xml:"constraint"`
+	} `xml:"constraints"`
+	Profiles struct {
+		Extra
+		Text    string    `xml:",chardata"`
+		Profile []Profile `xml:"profile"`
+	} `xml:"profiles"`
+	Rules struct {
+		Extra
+		Text string `xml:",chardata"`
+		Rule []Rule `xml:"rule"`
+	} `xml:"rules"`
+	InfoLinks struct {
+		Extra
+		Text     string     `xml:",chardata"`
+		InfoLink []InfoLink `xml:"infoLink"`
+	} `xml:"infoLinks"`
+	CategoryLinks struct {
+		Extra
+		Text         string         `xml:",chardata"`
+		CategoryLink []CategoryLink `xml:"categoryLink"`
+	} `xml:"categoryLinks"`
+	ForceEntries struct {
+		Extra
+		Text       string       `xml:",chardata"`
+		ForceEntry []ForceEntry `xml:"forceEntry"`
+	} `xml:"forceEntries"`
+}
+
+// ParseGameSystem decodes a single Battlescribe game system from r, which
+// may hold either a .gst file or a compressed .gstz file. Decoding failures
+// are returned as a *ParseError.
+func ParseGameSystem(r io.Reader) (*GameSystem, error) {
+	var gst GameSystem
+	sum, err := readData(r, &gst)
+	if err != nil {
+		return nil, err
+	}
+	gst.Source.SHA256 = sum
+
+	return &gst, nil
+}
@@ -0,0 +1,74 @@
+package bsdata_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/myminicommission/go-bsdata"
+)
+
+func TestParseGameSystem(t *testing.T) {
+	gst, err := bsdata.ParseGameSystem(bytes.NewReader(readFixture(t, "Test-System.gst")))
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if gst.ID != "gst-1" || gst.Name != "Test System" {
+		t.Errorf("unexpected game system %q %q", gst.ID, gst.Name)
+	}
+
+	if len(gst.CostTypes.CostType) != 1 || gst.CostTypes.CostType[0].Name != "pts" {
+		t.Errorf("expected the pts cost type, got %+v", gst.CostTypes.CostType)
+	}
+
+	if len(gst.ProfileTypes.ProfileType) != 2 {
+		t.Errorf("expected 2 profile types, found %d", len(gst.ProfileTypes.ProfileType))
+	}
+
+	if len(gst.CategoryEntries.CategoryEntry) != 2 {
+		t.Errorf("expected 2 category entries, found %d", len(gst.CategoryEntries.CategoryEntry))
+	}
+
+	if len(gst.ForceEntries.ForceEntry) != 1 {
+		t.Errorf("expected 1 force entry, found %d", len(gst.ForceEntries.ForceEntry))
+		t.FailNow()
+	}
+
+	nested := gst.ForceEntries.ForceEntry[0].ForceEntries.ForceEntry
+	if len(nested) != 1 || nested[0].Name != "Auxiliary" {
+		t.Errorf("expected the nested Auxiliary force entry, got %+v", nested)
+	}
+
+	if len(gst.SharedRules.Rule) != 1 || gst.SharedRules.Rule[0].Description != "Set up anywhere." {
+		t.Errorf("unexpected shared rules %+v", gst.SharedRules.Rule)
+	}
+}
+
+func TestParseGameSystemCompressed(t *testing.T) {
+	b := zipArchive(t, map[string][]byte{"Test-System.gst": readFixture(t, "Test-System.gst")})
+
+	gst, err := bsdata.ParseGameSystem(bytes.NewReader(b))
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if gst.ID != "gst-1" {
+		t.Errorf("unexpected game system id %q", gst.ID)
+	}
+}
+
+func TestLoadDirLinksGameSystem(t *testing.T) {
+	catalogues, err := bsdata.LoadDir("testdata/local")
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	for _, cat := range catalogues {
+		if cat.GameSystem == nil || cat.GameSystem.ID != cat.GameSystemId {
+			t.Errorf("expected %s to be linked to game system %s, got %+v", cat.Name, cat.GameSystemId, cat.GameSystem)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package bsdata_test
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/myminicommission/go-bsdata"
+)
+
+const importingCatalogue = `<catalogue id="cat-a" name="Army" gameSystemId="gst-1" library="false">
+  <catalogueLinks>
+    <catalogueLink id="cl-1" name="Armoury" targetId="lib-1" type="catalogue" importRootEntries="true"/>
+    <catalogueLink id="cl-2" name="Reference" targetId="lib-3" type="catalogue" importRootEntries="false"/>
+  </catalogueLinks>
+  <entryLinks>
+    <entryLink id="el-a" name="Own Unit" targetId="se-a" type="selectionEntry"/>
+  </entryLinks>
+</catalogue>`
+
+const armouryLibrary = `<catalogue id="lib-1" name="Armoury" gameSystemId="gst-1" library="true">
+  <catalogueLinks>
+    <catalogueLink id="cl-3" name="Vehicles" targetId="lib-2" type="catalogue" importRootEntries="true"/>
+  </catalogueLinks>
+  <entryLinks>
+    <entryLink id="el-1" name="Bolter" targetId="se-1" type="selectionEntry"/>
+  </entryLinks>
+</catalogue>`
+
+const vehicleLibrary = `<catalogue id="lib-2" name="Vehicles" gameSystemId="gst-1" library="true">
+  <catalogueLinks>
+    <catalogueLink id="cl-4" name="Armoury" targetId="lib-1" type="catalogue" importRootEntries="true"/>
+  </catalogueLinks>
+  <selectionEntries>
+    <selectionEntry id="se-2" name="Tank" type="unit"/>
+  </selectionEntries>
+</catalogue>`
+
+const referenceLibrary = `<catalogue id="lib-3" name="Reference" gameSystemId="gst-1" library="true">
+  <entryLinks>
+    <entryLink id="el-3" name="Not Imported" targetId="se-3" type="selectionEntry"/>
+  </entryLinks>
+</catalogue>`
+
+func TestLoadFSResolvesImports(t *testing.T) {
+	fsys := fstest.MapFS{
+		"Army.cat":      &fstest.MapFile{Data: []byte(importingCatalogue)},
+		"Armoury.cat":   &fstest.MapFile{Data: []byte(armouryLibrary)},
+		"Vehicles.cat":  &fstest.MapFile{Data: []byte(vehicleLibrary)},
+		"Reference.cat": &fstest.MapFile{Data: []byte(referenceLibrary)},
+	}
+
+	catalogues, err := bsdata.LoadFS(fsys)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	var army *bsdata.Catalogue
+	for _, cat := range catalogues {
+		if cat.ID == "cat-a" {
+			army = cat
+		}
+	}
+
+	if len(army.Imports) != 2 || army.Imports[0].ID != "lib-1" || army.Imports[1].ID != "lib-3" {
+		t.Errorf("expected imports of lib-1 and lib-3, got %d", len(army.Imports))
+	}
+
+	// root entries come from the armoury and, through it, the vehicles,
+	// but not from the reference library
+	if len(army.ImportedEntryLinks) != 1 || army.ImportedEntryLinks[0].Name != "Bolter" {
+		t.Errorf("expected the Bolter entry link, got %+v", army.ImportedEntryLinks)
+	}
+
+	if len(army.ImportedSelectionEntries) != 1 || army.ImportedSelectionEntries[0].Name != "Tank" {
+		t.Errorf("expected the Tank selection entry, got %+v", army.ImportedSelectionEntries)
+	}
+
+	if len(army.EntryLinks.EntryLink) != 1 {
+		t.Errorf("expected the catalogue's own entry links to be unchanged, got %d", len(army.EntryLinks.EntryLink))
+	}
+}
+
+func TestLoadFSMissingImport(t *testing.T) {
+	fsys := fstest.MapFS{
+		"Army.cat":    &fstest.MapFile{Data: []byte(importingCatalogue)},
+		"Armoury.cat": &fstest.MapFile{Data: []byte(armouryLibrary)},
+	}
+
+	catalogues, err := bsdata.LoadFS(fsys)
+	if len(catalogues) != 2 {
+		t.Errorf("expected 2 catalogues, found %d", len(catalogues))
+	}
+
+	var loadErrs bsdata.LoadErrors
+	if !errors.As(err, &loadErrs) || len(loadErrs) != 2 {
+		t.Errorf("expected 2 load errors, got %v", err)
+		t.FailNow()
+	}
+
+	var missing *bsdata.MissingImportError
+	if !errors.As(loadErrs[0], &missing) || missing.File != "Armoury.cat" || missing.TargetId != "lib-2" {
+		t.Errorf("unexpected first error %v", loadErrs[0])
+	}
+
+	if !errors.As(loadErrs[1], &missing) || missing.File != "Army.cat" || missing.TargetId != "lib-3" {
+		t.Errorf("unexpected second error %v", loadErrs[1])
+	}
+}
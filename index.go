@@ -0,0 +1,187 @@
+package bsdata
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/fs"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/osfs"
+	"github.com/go-git/go-billy/v5/util"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+)
+
+// DataIndex is a BattleScribe data index, as published in index.bsi files by
+// BattleScribe data repositories.
+type DataIndex struct {
+	XMLName             xml.Name         `xml:"dataIndex"`
+	Name                string           `xml:"name,attr"`
+	BattleScribeVersion string           `xml:"battleScribeVersion,attr"`
+	IndexURL            string           `xml:"indexUrl,attr"`
+	RepositoryURLs      []string         `xml:"repositoryUrls>repositoryUrl"`
+	Entries             []DataIndexEntry `xml:"dataIndexEntries>dataIndexEntry"`
+}
+
+// DataIndexEntry describes one data file listed in a DataIndex.
+type DataIndexEntry struct {
+	// FilePath is the location of the file, relative to the index.
+	FilePath                string `xml:"filePath,attr"`
+	DataType                string `xml:"dataType,attr"`
+	DataID                  string `xml:"dataId,attr"`
+	DataName                string `xml:"dataName,attr"`
+	DataBattleScribeVersion string `xml:"dataBattleScribeVersion,attr"`
+	DataRevision            string `xml:"dataRevision,attr"`
+}
+
+// ParseIndex decodes a BattleScribe data index from r. It accepts both the
+// zipped index.bsi form and a bare index.xml.
+func ParseIndex(r io.Reader) (*DataIndex, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if isZip(b) {
+		if b, err = unzipSingle(b, "index.xml"); err != nil {
+			return nil, err
+		}
+	}
+
+	var index DataIndex
+	if err := decodeXML(b, &index); err != nil {
+		return nil, err
+	}
+
+	return &index, nil
+}
+
+// GetIndexData downloads the BattleScribe data index at indexURL, fetches
+// every data file it lists and parses the catalogues among them.
+func GetIndexData(ctx context.Context, indexURL string) ([]*Catalogue, error) {
+	return defaultClient.GetIndexData(ctx, indexURL)
+}
+
+// FetchIndex downloads and parses the BattleScribe data index at indexURL.
+func (c *Client) FetchIndex(ctx context.Context, indexURL string) (*DataIndex, error) {
+	c.logger.Info("downloading index", "url", indexURL)
+
+	res, err := c.httpGet(ctx, indexURL)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	return ParseIndex(res.Body)
+}
+
+// GetIndexData downloads the BattleScribe data index at indexURL, fetches
+// every data file it lists and parses the catalogues among them.
+func (c *Client) GetIndexData(ctx context.Context, indexURL string) (catalogues []*Catalogue, err error) {
+	index, err := c.FetchIndex(ctx, indexURL)
+	if err != nil {
+		return nil, err
+	}
+
+	base, err := url.Parse(indexURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var wt billy.Filesystem
+	var fsys fs.FS
+	if c.inMemory {
+		wt = memfs.New()
+		fsys = billyFS{wt}
+	} else {
+		var dir string
+		dir, err = c.checkoutDir(repoKey(indexURL))
+		if err != nil {
+			return nil, err
+		}
+
+		// clean up once we are done, even if something failed
+		defer func() {
+			if cerr := os.RemoveAll(dir); cerr != nil && err == nil {
+				err = cerr
+			}
+		}()
+
+		wt = osfs.New(dir)
+		fsys = os.DirFS(dir)
+	}
+
+	for _, entry := range index.Entries {
+		if err := c.downloadIndexEntry(ctx, base, entry, wt); err != nil {
+			return nil, err
+		}
+	}
+
+	// the tree holds exactly the files the index lists
+	l := c.loader()
+	l.discovery = DiscoverOptions{Recursive: true}
+	l.logger = withFields(l.logger, "index", indexURL)
+	return onlyCatalogues(l.load(ctx, fsys))
+}
+
+// downloadIndexEntry fetches the file entry describes into wt.
+func (c *Client) downloadIndexEntry(ctx context.Context, base *url.URL, entry DataIndexEntry, wt billy.Filesystem) error {
+	// keep the entry's directory so files with the same name in different
+	// directories don't overwrite each other
+	name := path.Clean(entry.FilePath)
+	if !fs.ValidPath(name) || !isDataFile(name) {
+		return fmt.Errorf("bsdata: index entry %q is not a data file", entry.FilePath)
+	}
+
+	u := base.ResolveReference(&url.URL{Path: entry.FilePath})
+	c.logger.Info("downloading index entry", "file", entry.FilePath, "url", u.String())
+
+	res, err := c.httpGet(ctx, u.String())
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	return util.WriteFile(wt, name, b, 0644)
+}
+
+// httpGet requests url with the client's HTTP transport and credentials,
+// returning an error for anything but a 200 response.
+func (c *Client) httpGet(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if auth, ok := c.auth.(githttp.AuthMethod); ok {
+		auth.SetAuth(req)
+	}
+
+	client := http.DefaultClient
+	if rt := c.httpTransport(); rt != nil {
+		client = &http.Client{Transport: rt}
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+		return nil, &HTTPError{URL: url, StatusCode: res.StatusCode}
+	}
+
+	return res, nil
+}
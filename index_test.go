@@ -0,0 +1,121 @@
+package bsdata_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/myminicommission/go-bsdata"
+)
+
+const testIndex = `<?xml version="1.0" encoding="UTF-8"?>
+<dataIndex xmlns="http://www.battlescribe.net/schema/dataIndexSchema" battleScribeVersion="2.03" name="Test Data" indexUrl="http://example.com/data/index.bsi">
+  <repositoryUrls>
+    <repositoryUrl>http://example.com/data/index.bsi</repositoryUrl>
+  </repositoryUrls>
+  <dataIndexEntries>
+    <dataIndexEntry filePath="Test System.gstz" dataType="gamesystem" dataId="gst-1" dataName="Test System" dataBattleScribeVersion="2.03" dataRevision="2"/>
+    <dataIndexEntry filePath="Test Faction.catz" dataType="catalogue" dataId="cat-1" dataName="Test Faction" dataBattleScribeVersion="2.03" dataRevision="3"/>
+    <dataIndexEntry filePath="Test Library.cat" dataType="catalogue" dataId="cat-2" dataName="Test Library" dataBattleScribeVersion="2.03" dataRevision="1"/>
+  </dataIndexEntries>
+</dataIndex>`
+
+func TestParseIndex(t *testing.T) {
+	index, err := bsdata.ParseIndex(strings.NewReader(testIndex))
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if index.Name != "Test Data" || len(index.RepositoryURLs) != 1 {
+		t.Errorf("unexpected index %+v", index)
+	}
+
+	if len(index.Entries) != 3 {
+		t.Errorf("expected 3 entries, found %d", len(index.Entries))
+		t.FailNow()
+	}
+
+	if index.Entries[1].FilePath != "Test Faction.catz" || index.Entries[1].DataRevision != "3" {
+		t.Errorf("unexpected entry %+v", index.Entries[1])
+	}
+}
+
+func TestClientGetIndexData(t *testing.T) {
+	files := map[string][]byte{
+		"/data/index.bsi":         zipArchive(t, map[string][]byte{"index.xml": []byte(testIndex)}),
+		"/data/Test System.gstz":  zipArchive(t, map[string][]byte{"Test System.gst": readFixture(t, "Test-System.gst")}),
+		"/data/Test Faction.catz": zipArchive(t, map[string][]byte{"Test Faction.cat": readFixture(t, "Test-Faction.cat")}),
+		"/data/Test Library.cat":  readFixture(t, "Test-Library.cat"),
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(b)
+	}))
+	defer srv.Close()
+
+	for _, inMemory := range []bool{false, true} {
+		opts := []bsdata.Option{bsdata.WithWorkDir(t.TempDir())}
+		if inMemory {
+			opts = append(opts, bsdata.WithInMemory())
+		}
+
+		catalogues, err := bsdata.NewClient(opts...).GetIndexData(context.Background(), srv.URL+"/data/index.bsi")
+		if err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+
+		if len(catalogues) != 2 {
+			t.Errorf("expected 2 catalogues, found %d", len(catalogues))
+		}
+	}
+}
+
+func TestClientGetIndexDataSubdirectories(t *testing.T) {
+	index := `<dataIndex battleScribeVersion="2.03" name="Test Data">
+  <dataIndexEntries>
+    <dataIndexEntry filePath="Test.cat" dataType="catalogue" dataId="cat-1" dataName="Test Faction"/>
+    <dataIndexEntry filePath="Legacy/Test.cat" dataType="catalogue" dataId="cat-2" dataName="Test Library"/>
+  </dataIndexEntries>
+</dataIndex>`
+	files := map[string][]byte{
+		"/data/index.bsi":       zipArchive(t, map[string][]byte{"index.xml": []byte(index)}),
+		"/data/Test.cat":        readFixture(t, "Test-Faction.cat"),
+		"/data/Legacy/Test.cat": readFixture(t, "Test-Library.cat"),
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(b)
+	}))
+	defer srv.Close()
+
+	for _, inMemory := range []bool{false, true} {
+		opts := []bsdata.Option{bsdata.WithWorkDir(t.TempDir())}
+		if inMemory {
+			opts = append(opts, bsdata.WithInMemory())
+		}
+
+		catalogues, err := bsdata.NewClient(opts...).GetIndexData(context.Background(), srv.URL+"/data/index.bsi")
+		if err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+
+		if len(catalogues) != 2 {
+			t.Errorf("expected both files with the same name to be loaded, found %d catalogues", len(catalogues))
+		}
+	}
+}
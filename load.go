@@ -0,0 +1,242 @@
+package bsdata
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"runtime"
+	"sync"
+)
+
+// LoadDir parses the Battlescribe catalogues found in an existing local
+// checkout at path. Unlike GetData it never touches git or the network.
+func LoadDir(path string) ([]*Catalogue, error) {
+	return LoadFS(os.DirFS(path))
+}
+
+// LoadFS parses the Battlescribe catalogues found in the root of fsys, which
+// may be an embed.FS, a zip.Reader or any other fs.FS implementation. Files
+// are parsed in parallel; see loader.load for how failures are reported.
+func LoadFS(fsys fs.FS) ([]*Catalogue, error) {
+	return onlyCatalogues(defaultLoader().load(context.Background(), fsys))
+}
+
+// LoadFS parses the Battlescribe catalogues found in fsys using the
+// client's discovery options, workers, logger and progress reporting.
+func (c *Client) LoadFS(fsys fs.FS) ([]*Catalogue, error) {
+	return onlyCatalogues(c.loader().load(context.Background(), fsys))
+}
+
+// onlyCatalogues returns the catalogues of ds, which may be nil, along with err.
+func onlyCatalogues(ds *Dataset, err error) ([]*Catalogue, error) {
+	if ds == nil {
+		return nil, err
+	}
+
+	return ds.Catalogues, err
+}
+
+// loader parses the data files found in a filesystem.
+type loader struct {
+	logger Logger
+	// workers is the number of files parsed at once.
+	workers   int
+	progress  *progressReporter
+	discovery DiscoverOptions
+	// strict rejects files holding XML the model does not consume.
+	strict bool
+}
+
+func defaultLoader() loader {
+	return loader{
+		logger:  nopLogger{},
+		workers: runtime.GOMAXPROCS(0),
+	}
+}
+
+// load parses the catalogues and game system Discover finds in fsys using a
+// bounded pool of workers. The catalogues are returned in path order, and
+// if there are several game systems the first is used. Files that fail do
+// not stop the others: the dataset holding what did parse is returned
+// together with a LoadErrors holding one error per failed file and per
+// catalogue link whose target was not loaded.
+func (l loader) load(ctx context.Context, fsys fs.FS) (*Dataset, error) {
+	// get the data files
+	files, err := discover(fsys, l.discovery, l.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed := make([]interface{}, len(files))
+	errs := make([]error, len(files))
+
+	workers := l.workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	var mu sync.Mutex
+	var done int64
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range jobs {
+				l.logger.Debug("parsing file", "file", files[i].Path)
+				parsed[i], errs[i] = l.parseDataFile(fsys, files[i])
+
+				mu.Lock()
+				done++
+				l.progress.report(Progress{
+					Phase:   PhaseParsing,
+					Current: done,
+					Total:   int64(len(files)),
+					Message: files[i].Path,
+				})
+				mu.Unlock()
+			}
+		}()
+	}
+
+	// hand out the data files until they run out or we are cancelled
+	for i := range files {
+		if ctx.Err() != nil {
+			break
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// keep the parsed files in path order and collect the failures
+	ds := &Dataset{}
+	var failed LoadErrors
+	for i, v := range parsed {
+		if errs[i] != nil {
+			failed = append(failed, errs[i])
+			continue
+		}
+
+		switch v := v.(type) {
+		case *Catalogue:
+			l.logger.Debug("parsed catalogue", "file", files[i].Path, "catalogue", v.Name)
+			ds.Catalogues = append(ds.Catalogues, v)
+		case *GameSystem:
+			l.logger.Debug("parsed game system", "file", files[i].Path, "gameSystem", v.Name)
+			if ds.GameSystem == nil {
+				ds.GameSystem = v
+			}
+		}
+	}
+
+	ds.linkGameSystem(l.logger)
+	failed = append(failed, ds.resolveImports()...)
+
+	if len(failed) > 0 {
+		return ds, failed
+	}
+
+	return ds, nil
+}
+
+// ParseCatalogue decodes a single Battlescribe catalogue from r, which may
+// hold either a .cat file or a compressed .catz file. Decoding failures are
+// returned as a *ParseError.
+func ParseCatalogue(r io.Reader) (*Catalogue, error) {
+	var cat Catalogue
+	sum, err := readData(r, &cat)
+	if err != nil {
+		return nil, err
+	}
+	cat.Source.SHA256 = sum
+
+	return &cat, nil
+}
+
+// readData decodes the data file read from r into v, decompressing it
+// first if needed. It returns the SHA-256 of the file as read.
+func readData(r io.Reader, v interface{}) (string, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+
+	if b, err = decompress(b); err != nil {
+		return "", err
+	}
+
+	if err := decodeXML(b, v); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// decodeXML unmarshals b into v, reporting failures as a *ParseError that
+// records where in b decoding stopped.
+func decodeXML(b []byte, v interface{}) error {
+	d := xml.NewDecoder(bytes.NewReader(b))
+	if err := d.Decode(v); err != nil {
+		offset := d.InputOffset()
+		line := 1 + bytes.Count(b[:offset], []byte("\n"))
+		if serr, ok := err.(*xml.SyntaxError); ok {
+			line = serr.Line
+		}
+
+		return &ParseError{Line: line, Offset: offset, Err: err}
+	}
+
+	return nil
+}
+
+// parseDataFile parses the catalogue or game system f, recording the file
+// it came from. It returns a *Catalogue or a *GameSystem.
+func (l loader) parseDataFile(fsys fs.FS, f DataFile) (interface{}, error) {
+	r, err := fsys.Open(f.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	var v interface{}
+	if f.Type.isCatalogue() {
+		var cat *Catalogue
+		if cat, err = ParseCatalogue(r); err == nil {
+			cat.Source.Path = f.Path
+			v = cat
+		}
+	} else {
+		var gst *GameSystem
+		if gst, err = ParseGameSystem(r); err == nil {
+			gst.Source.Path = f.Path
+			v = gst
+		}
+	}
+
+	var perr *ParseError
+	if errors.As(err, &perr) {
+		perr.File = f.Path
+	}
+
+	if err == nil && l.strict {
+		if unconsumed := FindUnconsumed(v); len(unconsumed) > 0 {
+			return nil, &StrictError{File: f.Path, Unconsumed: unconsumed}
+		}
+	}
+
+	return v, err
+}
@@ -0,0 +1,158 @@
+package bsdata_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/myminicommission/go-bsdata"
+)
+
+func TestLoadDir(t *testing.T) {
+	catalogues, err := bsdata.LoadDir("testdata/local")
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if len(catalogues) != 2 {
+		t.Errorf("expected 2 catalogues, found %d", len(catalogues))
+		t.FailNow()
+	}
+
+	if catalogues[0].Name != "Test Faction" {
+		t.Errorf("unexpected first catalogue %q", catalogues[0].Name)
+	}
+}
+
+func TestLoadDirMissing(t *testing.T) {
+	_, err := bsdata.LoadDir("testdata/does-not-exist")
+	if err == nil {
+		t.Error("expected an error for a missing directory")
+	}
+}
+
+func TestLoadFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"Test-Faction.cat": &fstest.MapFile{Data: readFixture(t, "Test-Faction.cat")},
+		"notes.txt":        &fstest.MapFile{Data: []byte("not a catalogue")},
+	}
+
+	catalogues, err := bsdata.LoadFS(fsys)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if len(catalogues) != 1 {
+		t.Errorf("expected 1 catalogue, found %d", len(catalogues))
+		t.FailNow()
+	}
+
+	if catalogues[0].ID != "cat-1" {
+		t.Errorf("unexpected catalogue id %q", catalogues[0].ID)
+	}
+}
+
+func TestParseCatalogue(t *testing.T) {
+	cat, err := bsdata.ParseCatalogue(bytes.NewReader(readFixture(t, "Test-Faction.cat")))
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if cat.Name != "Test Faction" {
+		t.Errorf("unexpected catalogue name %q", cat.Name)
+	}
+
+	if len(cat.SharedSelectionEntries.SelectionEntry) != 1 {
+		t.Errorf("expected 1 shared selection entry, found %d", len(cat.SharedSelectionEntries.SelectionEntry))
+	}
+}
+
+func TestParseCatalogueInvalid(t *testing.T) {
+	_, err := bsdata.ParseCatalogue(strings.NewReader("<catalogue"))
+	if err == nil {
+		t.Error("expected an error for truncated xml")
+	}
+}
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+
+	b, err := os.ReadFile(filepath.Join("testdata", "local", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return b
+}
+
+func TestLoadFSCompressed(t *testing.T) {
+	fsys := fstest.MapFS{
+		"Test-Faction.catz": &fstest.MapFile{Data: zipArchive(t, map[string][]byte{
+			"Test-Faction.cat": readFixture(t, "Test-Faction.cat"),
+		})},
+		"Test-Library.cat": &fstest.MapFile{Data: readFixture(t, "Test-Library.cat")},
+	}
+
+	catalogues, err := bsdata.LoadFS(fsys)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if len(catalogues) != 2 {
+		t.Errorf("expected 2 catalogues, found %d", len(catalogues))
+		t.FailNow()
+	}
+
+	if catalogues[0].Name != "Test Faction" {
+		t.Errorf("expected the compressed catalogue to be decoded, got %q", catalogues[0].Name)
+	}
+}
+
+func TestLoadFSCollectsErrors(t *testing.T) {
+	fsys := fstest.MapFS{}
+	for i := 0; i < 20; i++ {
+		name := fmt.Sprintf("Faction-%02d.cat", i)
+		data := []byte(fmt.Sprintf(`<catalogue id="cat-%02d" name="Faction %02d"/>`, i, i))
+		if i%7 == 3 {
+			data = []byte("<catalogue")
+		}
+		fsys[name] = &fstest.MapFile{Data: data}
+	}
+
+	catalogues, err := bsdata.LoadFS(fsys)
+
+	var errs bsdata.LoadErrors
+	if !errors.As(err, &errs) {
+		t.Errorf("expected LoadErrors, got %v", err)
+		t.FailNow()
+	}
+
+	if len(errs) != 3 {
+		t.Errorf("expected 3 failed files, got %d", len(errs))
+	}
+
+	var perr *bsdata.ParseError
+	if !errors.As(errs[0], &perr) || perr.File != "Faction-03.cat" {
+		t.Errorf("expected the first failure to be Faction-03.cat, got %v", errs[0])
+	}
+
+	if len(catalogues) != 17 {
+		t.Errorf("expected 17 catalogues, found %d", len(catalogues))
+		t.FailNow()
+	}
+
+	for i := 1; i < len(catalogues); i++ {
+		if catalogues[i-1].ID >= catalogues[i].ID {
+			t.Errorf("catalogues out of order: %s before %s", catalogues[i-1].ID, catalogues[i].ID)
+		}
+	}
+}
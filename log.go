@@ -0,0 +1,90 @@
+package bsdata
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Logger receives the package's diagnostic messages, each made of a message
+// and alternating keys and values such as "repo", "wh40k". A *slog.Logger
+// satisfies it, and LogrusLogger adapts a logrus logger.
+type Logger interface {
+	Debug(msg string, keysAndValues ...interface{})
+	Info(msg string, keysAndValues ...interface{})
+}
+
+// nopLogger discards everything. It is the default, so the package is silent
+// unless a logger is configured with WithLogger.
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...interface{}) {}
+func (nopLogger) Info(string, ...interface{})  {}
+
+// fieldLogger adds a fixed set of keys and values to every message.
+type fieldLogger struct {
+	next   Logger
+	fields []interface{}
+}
+
+// withFields returns a logger that adds keysAndValues to every message sent
+// to l.
+func withFields(l Logger, keysAndValues ...interface{}) Logger {
+	if _, ok := l.(nopLogger); ok {
+		return l
+	}
+
+	return &fieldLogger{next: l, fields: keysAndValues}
+}
+
+func (l *fieldLogger) Debug(msg string, keysAndValues ...interface{}) {
+	l.next.Debug(msg, l.with(keysAndValues)...)
+}
+
+func (l *fieldLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.next.Info(msg, l.with(keysAndValues)...)
+}
+
+func (l *fieldLogger) with(keysAndValues []interface{}) []interface{} {
+	all := make([]interface{}, 0, len(l.fields)+len(keysAndValues))
+	all = append(all, l.fields...)
+
+	return append(all, keysAndValues...)
+}
+
+// logrusLogger adapts a logrus logger to Logger, turning keys and values
+// into logrus fields.
+type logrusLogger struct {
+	l logrus.FieldLogger
+}
+
+// LogrusLogger returns a Logger that writes to the logrus logger l.
+func LogrusLogger(l logrus.FieldLogger) Logger {
+	return logrusLogger{l: l}
+}
+
+func (l logrusLogger) Debug(msg string, keysAndValues ...interface{}) {
+	l.entry(keysAndValues).Debug(msg)
+}
+
+func (l logrusLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.entry(keysAndValues).Info(msg)
+}
+
+func (l logrusLogger) entry(keysAndValues []interface{}) logrus.FieldLogger {
+	if len(keysAndValues) == 0 {
+		return l.l
+	}
+
+	fields := logrus.Fields{}
+	for i := 0; i < len(keysAndValues); i += 2 {
+		key := fmt.Sprint(keysAndValues[i])
+		if i+1 < len(keysAndValues) {
+			fields[key] = keysAndValues[i+1]
+		} else {
+			fields[key] = nil
+		}
+	}
+
+	return l.l.WithFields(fields)
+}
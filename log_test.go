@@ -0,0 +1,102 @@
+package bsdata_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+
+	"github.com/myminicommission/go-bsdata"
+)
+
+// recordingLogger keeps every message it receives with its fields.
+type recordingLogger struct {
+	mu       sync.Mutex
+	messages []map[string]interface{}
+}
+
+func (l *recordingLogger) Debug(msg string, keysAndValues ...interface{}) {
+	l.record(msg, keysAndValues)
+}
+
+func (l *recordingLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.record(msg, keysAndValues)
+}
+
+func (l *recordingLogger) record(msg string, keysAndValues []interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	fields := map[string]interface{}{"msg": msg}
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		fields[fmt.Sprint(keysAndValues[i])] = keysAndValues[i+1]
+	}
+	l.messages = append(l.messages, fields)
+}
+
+func TestClientLoggerFields(t *testing.T) {
+	base := newTestRemote(t, "test-repo", "1.0.0")
+	logger := &recordingLogger{}
+	client := bsdata.NewClient(
+		bsdata.WithBaseURL("file://"+base),
+		bsdata.WithWorkDir(t.TempDir()),
+		bsdata.WithLogger(logger),
+	)
+
+	if _, err := client.GetData("test-repo", "1.0.0"); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	var parsed int
+	for _, m := range logger.messages {
+		if m["repo"] != "test-repo" {
+			t.Errorf("expected every message to carry the repo, got %v", m)
+		}
+
+		if m["msg"] == "parsed catalogue" {
+			parsed++
+			if m["file"] == nil || m["ref"] != "tag 1.0.0" {
+				t.Errorf("expected file and ref fields, got %v", m)
+			}
+		}
+	}
+
+	if parsed != 2 {
+		t.Errorf("expected 2 parsed catalogue messages, got %d", parsed)
+	}
+}
+
+func TestLogrusLogger(t *testing.T) {
+	l, hook := test.NewNullLogger()
+	l.SetLevel(logrus.DebugLevel)
+
+	logger := bsdata.LogrusLogger(l)
+	logger.Info("cloning repository", "repo", "wh40k", "ref", "tag 9.0.0")
+
+	entry := hook.LastEntry()
+	if entry == nil {
+		t.Error("expected a log entry")
+		t.FailNow()
+	}
+
+	if entry.Message != "cloning repository" || entry.Data["repo"] != "wh40k" || entry.Data["ref"] != "tag 9.0.0" {
+		t.Errorf("unexpected entry %q %v", entry.Message, entry.Data)
+	}
+}
+
+func TestDefaultLoggerIsSilent(t *testing.T) {
+	hook := test.NewGlobal()
+	defer hook.Reset()
+
+	if _, err := bsdata.LoadDir("testdata/local"); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if len(hook.AllEntries()) != 0 {
+		t.Errorf("expected no log output, got %d entries", len(hook.AllEntries()))
+	}
+}
@@ -0,0 +1,211 @@
+package bsdata
+
+import (
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ProgressPhase identifies what a Progress event reports on.
+type ProgressPhase string
+
+const (
+	// PhaseCounting is the remote counting the objects it will send.
+	PhaseCounting ProgressPhase = "counting"
+	// PhaseCompressing is the remote compressing the objects it will send.
+	PhaseCompressing ProgressPhase = "compressing"
+	// PhaseReceiving is data arriving from the remote. Bytes holds the
+	// number of bytes received so far. Current and Total count objects, and
+	// are only set by progress lines from the remote.
+	PhaseReceiving ProgressPhase = "receiving"
+	// PhaseResolving is deltas being resolved into objects.
+	PhaseResolving ProgressPhase = "resolving"
+	// PhaseParsing is data files being parsed. Current and Total count
+	// files.
+	PhaseParsing ProgressPhase = "parsing"
+)
+
+// Progress reports how far a fetch has got.
+type Progress struct {
+	Phase ProgressPhase
+	// Current and Total count the objects or files handled in this phase.
+	// Both are zero when the event only reports bytes, and Total is zero
+	// when it is not known.
+	Current int64
+	Total   int64
+	// Bytes is the number of bytes transferred so far, when known. Sizes
+	// reported by the remote are rounded to the precision git prints.
+	Bytes int64
+	// Message is the remote's progress line or the file being parsed.
+	Message string
+}
+
+// ProgressFunc receives progress events. Calls are never made concurrently.
+type ProgressFunc func(Progress)
+
+// WithProgress reports the progress of cloning, downloading and parsing to
+// fn. Without it progress is discarded. Byte counts for HTTP(S) git remotes
+// come from a wrapped round tripper, which reaches go-git as described on
+// WithHTTPTransport.
+func WithProgress(fn ProgressFunc) Option {
+	return func(c *Client) {
+		c.progress = newProgressReporter(fn)
+	}
+}
+
+// progressReporter serialises calls to a ProgressFunc. A nil reporter
+// discards everything.
+type progressReporter struct {
+	mu sync.Mutex
+	fn ProgressFunc
+}
+
+func newProgressReporter(fn ProgressFunc) *progressReporter {
+	if fn == nil {
+		return nil
+	}
+
+	return &progressReporter{fn: fn}
+}
+
+func (p *progressReporter) report(ev Progress) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.fn(ev)
+}
+
+// sideband returns a writer for go-git's sideband progress messages, or
+// nil if progress is discarded.
+func (p *progressReporter) sideband() io.Writer {
+	if p == nil {
+		return nil
+	}
+
+	return &sidebandWriter{p: p}
+}
+
+// sidebandLine matches git progress lines such as
+// "Counting objects:  50% (5/10)" and "Receiving objects: 100% (10/10), 2.5 KiB".
+var sidebandLine = regexp.MustCompile(`^(?:remote: )?(\w+) (?:objects|deltas):\s+\d+% \((\d+)/(\d+)\)(?:, (\d+(?:\.\d+)?) (bytes?|KiB|MiB|GiB))?`)
+
+// sidebandUnits maps the size units in git progress lines to bytes.
+var sidebandUnits = map[string]float64{
+	"byte":  1,
+	"bytes": 1,
+	"KiB":   1 << 10,
+	"MiB":   1 << 20,
+	"GiB":   1 << 30,
+}
+
+var sidebandPhases = map[string]ProgressPhase{
+	"Enumerating": PhaseCounting,
+	"Counting":    PhaseCounting,
+	"Compressing": PhaseCompressing,
+	"Receiving":   PhaseReceiving,
+	"Resolving":   PhaseResolving,
+}
+
+// sidebandWriter turns the remote's progress lines into Progress events.
+type sidebandWriter struct {
+	p   *progressReporter
+	buf string
+}
+
+func (w *sidebandWriter) Write(b []byte) (int, error) {
+	w.buf += string(b)
+	for {
+		i := strings.IndexAny(w.buf, "\r\n")
+		if i < 0 {
+			break
+		}
+
+		line := strings.TrimSpace(w.buf[:i])
+		w.buf = w.buf[i+1:]
+		if line != "" {
+			w.line(line)
+		}
+	}
+
+	return len(b), nil
+}
+
+func (w *sidebandWriter) line(line string) {
+	m := sidebandLine.FindStringSubmatch(line)
+	if m == nil {
+		return
+	}
+
+	phase, ok := sidebandPhases[m[1]]
+	if !ok {
+		return
+	}
+
+	current, _ := strconv.ParseInt(m[2], 10, 64)
+	total, _ := strconv.ParseInt(m[3], 10, 64)
+
+	var bytes int64
+	if m[4] != "" {
+		size, _ := strconv.ParseFloat(m[4], 64)
+		bytes = int64(size * sidebandUnits[m[5]])
+	}
+
+	w.p.report(Progress{Phase: phase, Current: current, Total: total, Bytes: bytes, Message: line})
+}
+
+// countingTransport reports the bytes of every response body it reads as
+// PhaseReceiving progress. It leaves Current and Total alone, since those
+// count objects in that phase.
+type countingTransport struct {
+	next http.RoundTripper
+	p    *progressReporter
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	res.Body = &countingReader{ReadCloser: res.Body, p: t.p, url: req.URL.String()}
+
+	return res, nil
+}
+
+type countingReader struct {
+	io.ReadCloser
+	p    *progressReporter
+	read int64
+	url  string
+}
+
+func (r *countingReader) Read(b []byte) (int, error) {
+	n, err := r.ReadCloser.Read(b)
+	if n > 0 {
+		r.read += int64(n)
+		r.p.report(Progress{Phase: PhaseReceiving, Bytes: r.read, Message: r.url})
+	}
+
+	return n, err
+}
+
+// httpTransport returns the round tripper for the client's HTTP requests,
+// counting received bytes when progress is reported.
+func (c *Client) httpTransport() http.RoundTripper {
+	if c.progress == nil {
+		return c.transport
+	}
+
+	next := c.transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &countingTransport{next: next, p: c.progress}
+}
@@ -0,0 +1,127 @@
+package bsdata_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/myminicommission/go-bsdata"
+)
+
+func TestClientProgress(t *testing.T) {
+	archive := zipArchive(t, map[string][]byte{
+		"test-repo-1.0.0/Test-Faction.cat": readFixture(t, "Test-Faction.cat"),
+		"test-repo-1.0.0/Test-Library.cat": readFixture(t, "Test-Library.cat"),
+	})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(archive)
+	}))
+	defer srv.Close()
+
+	var events []bsdata.Progress
+	client := bsdata.NewClient(
+		bsdata.WithBaseURL(srv.URL),
+		bsdata.WithWorkDir(t.TempDir()),
+		bsdata.WithArchives(bsdata.ArchiveZip),
+		bsdata.WithProgress(func(p bsdata.Progress) {
+			events = append(events, p)
+		}),
+	)
+
+	if _, err := client.GetData("test-repo", "1.0.0"); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	var received int64
+	var parsed []bsdata.Progress
+	for _, ev := range events {
+		switch ev.Phase {
+		case bsdata.PhaseReceiving:
+			received = ev.Bytes
+			if ev.Current != 0 || ev.Total != 0 {
+				t.Errorf("expected no object counts with received bytes, got %d of %d", ev.Current, ev.Total)
+			}
+		case bsdata.PhaseParsing:
+			parsed = append(parsed, ev)
+		}
+	}
+
+	if received != int64(len(archive)) {
+		t.Errorf("expected %d bytes received, got %d", len(archive), received)
+	}
+
+	if len(parsed) != 2 {
+		t.Errorf("expected 2 parsing events, got %d", len(parsed))
+		t.FailNow()
+	}
+
+	last := parsed[len(parsed)-1]
+	if last.Current != 2 || last.Total != 2 {
+		t.Errorf("expected to finish at file 2 of 2, got %d of %d", last.Current, last.Total)
+	}
+}
+
+func TestClientCloneProgress(t *testing.T) {
+	base := newTestRemote(t, "test-repo", "1.0.0")
+
+	phases := map[bsdata.ProgressPhase]int{}
+	client := bsdata.NewClient(
+		bsdata.WithBaseURL("file://"+base),
+		bsdata.WithWorkDir(t.TempDir()),
+		bsdata.WithProgress(func(p bsdata.Progress) {
+			phases[p.Phase]++
+		}),
+	)
+
+	if _, err := client.GetData("test-repo", "1.0.0"); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if phases[bsdata.PhaseCounting] == 0 {
+		t.Errorf("expected the remote's counting progress to be reported, got %v", phases)
+	}
+
+	// two catalogues and the game system
+	if phases[bsdata.PhaseParsing] != 3 {
+		t.Errorf("expected 3 parsing events, got %d", phases[bsdata.PhaseParsing])
+	}
+}
+
+// TestClientProgressConcurrent runs a client reporting progress over HTTP
+// next to a plain client. Run it with -race to check that reporting progress
+// does not write the shared go-git protocols.
+func TestClientProgressConcurrent(t *testing.T) {
+	base := newTestRemote(t, "test-repo", "1.0.0")
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	progressClient := bsdata.NewClient(
+		bsdata.WithBaseURL(srv.URL),
+		bsdata.WithWorkDir(t.TempDir()),
+		bsdata.WithProgress(func(bsdata.Progress) {}),
+	)
+	plainClient := bsdata.NewClient(
+		bsdata.WithBaseURL("file://"+base),
+		bsdata.WithWorkDir(t.TempDir()),
+	)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		if _, err := progressClient.ListTags("test-repo"); !errors.Is(err, bsdata.ErrRepoNotFound) {
+			t.Errorf("expected ErrRepoNotFound, got %v", err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		if _, err := plainClient.ListTags("test-repo"); err != nil {
+			t.Error(err)
+		}
+	}()
+	wg.Wait()
+}
@@ -0,0 +1,143 @@
+package bsdata
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// RefKind is the kind of revision a Ref selects.
+type RefKind int
+
+const (
+	// RefHead selects the remote's default branch.
+	RefHead RefKind = iota
+	// RefTag selects a tag.
+	RefTag
+	// RefBranch selects a branch.
+	RefBranch
+	// RefCommit selects a commit by its full SHA.
+	RefCommit
+)
+
+// Ref selects the revision of a data repository to load. The zero Ref
+// selects the remote's default branch.
+type Ref struct {
+	Kind RefKind
+	Name string
+}
+
+// Tag returns a Ref selecting the tag name.
+func Tag(name string) Ref {
+	return Ref{Kind: RefTag, Name: name}
+}
+
+// Branch returns a Ref selecting the branch name.
+func Branch(name string) Ref {
+	return Ref{Kind: RefBranch, Name: name}
+}
+
+// Commit returns a Ref selecting the commit with the full SHA hash.
+func Commit(hash string) Ref {
+	return Ref{Kind: RefCommit, Name: hash}
+}
+
+// tagRef converts the tag arguments of GetData into a Ref.
+func tagRef(tag string) Ref {
+	if tag == "" {
+		return Ref{}
+	}
+
+	return Tag(tag)
+}
+
+func (r Ref) String() string {
+	switch r.Kind {
+	case RefTag:
+		return "tag " + r.Name
+	case RefBranch:
+		return "branch " + r.Name
+	case RefCommit:
+		return "commit " + r.Name
+	default:
+		return "HEAD"
+	}
+}
+
+// refSpecs returns the refspecs that fetch exactly r.
+func (r Ref) refSpecs() []config.RefSpec {
+	switch r.Kind {
+	case RefTag:
+		return []config.RefSpec{config.RefSpec(fmt.Sprintf("+refs/tags/%s:refs/tags/%[1]s", r.Name))}
+	case RefBranch:
+		return []config.RefSpec{config.RefSpec(fmt.Sprintf("+refs/heads/%s:refs/heads/%[1]s", r.Name))}
+	case RefCommit:
+		return []config.RefSpec{config.RefSpec(fmt.Sprintf("%s:refs/heads/bsdata-commit", r.Name))}
+	default:
+		return []config.RefSpec{"+HEAD:refs/remotes/origin/HEAD"}
+	}
+}
+
+// notFound returns the error reported when r does not exist in repo.
+func (r Ref) notFound(repo string) error {
+	if r.Kind == RefTag {
+		return fmt.Errorf("%w: %s", ErrTagNotFound, r.Name)
+	}
+
+	return fmt.Errorf("%w: %s in %s", ErrRefNotFound, r, repo)
+}
+
+// resolveRef returns the commit ref points at in a local repository,
+// peeling annotated tags.
+func resolveRef(r *git.Repository, repo string, ref Ref) (*object.Commit, error) {
+	var hash plumbing.Hash
+	switch ref.Kind {
+	case RefTag:
+		tag, err := r.Tag(ref.Name)
+		if errors.Is(err, git.ErrTagNotFound) {
+			return nil, ref.notFound(repo)
+		}
+		if err != nil {
+			return nil, err
+		}
+		hash = tag.Hash()
+	case RefBranch:
+		branch, err := r.Reference(plumbing.NewBranchReferenceName(ref.Name), true)
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return nil, ref.notFound(repo)
+		}
+		if err != nil {
+			return nil, err
+		}
+		hash = branch.Hash()
+	case RefCommit:
+		if !plumbing.IsHash(ref.Name) {
+			return nil, ref.notFound(repo)
+		}
+		hash = plumbing.NewHash(ref.Name)
+	default:
+		head, err := r.Reference(plumbing.NewRemoteReferenceName("origin", "HEAD"), true)
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			head, err = r.Head()
+		}
+		if err != nil {
+			return nil, err
+		}
+		hash = head.Hash()
+	}
+
+	if t, err := r.TagObject(hash); err == nil {
+		return t.Commit()
+	}
+
+	commit, err := r.CommitObject(hash)
+	if errors.Is(err, plumbing.ErrObjectNotFound) {
+		return nil, ref.notFound(repo)
+	}
+
+	return commit, err
+}
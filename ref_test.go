@@ -0,0 +1,103 @@
+package bsdata_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+
+	"github.com/myminicommission/go-bsdata"
+)
+
+func TestClientGetDataRef(t *testing.T) {
+	base := newTestRemote(t, "test-repo", "1.0.0")
+
+	r, err := git.PlainOpen(filepath.Join(base, "test-repo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, err := r.Head()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// move the default branch on past the tag
+	commitTestFiles(t, r, map[string][]byte{
+		"Extra.cat": readFixture(t, "Test-Library.cat"),
+	})
+	head, err := r.Head()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// leave a branch other than the default behind at the tag
+	dev := plumbing.NewHashReference(plumbing.NewBranchReferenceName("dev"), first.Hash())
+	if err := r.Storer.SetReference(dev); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		ref        bsdata.Ref
+		cached     bool
+		catalogues int
+		commit     string
+	}{
+		{"default branch", bsdata.Ref{}, false, 3, head.Hash().String()},
+		{"tag", bsdata.Tag("1.0.0"), false, 2, first.Hash().String()},
+		{"branch", bsdata.Branch(head.Name().Short()), false, 3, head.Hash().String()},
+		{"other branch", bsdata.Branch("dev"), false, 2, first.Hash().String()},
+		{"commit", bsdata.Commit(first.Hash().String()), false, 2, first.Hash().String()},
+		{"cached default branch", bsdata.Ref{}, true, 3, head.Hash().String()},
+		{"cached other branch", bsdata.Branch("dev"), true, 2, first.Hash().String()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []bsdata.Option{
+				bsdata.WithBaseURL("file://" + base),
+				bsdata.WithWorkDir(t.TempDir()),
+			}
+			if tt.cached {
+				// a fresh cache, so the ref must resolve on the first call
+				opts = append(opts, bsdata.WithCache(bsdata.CacheConfig{Dir: t.TempDir()}))
+			}
+			client := bsdata.NewClient(opts...)
+
+			catalogues, commit, err := client.GetDataRef(context.Background(), "test-repo", tt.ref)
+			if err != nil {
+				t.Error(err)
+				t.FailNow()
+			}
+
+			if len(catalogues) != tt.catalogues {
+				t.Errorf("expected %d catalogues, found %d", tt.catalogues, len(catalogues))
+			}
+
+			if commit != tt.commit {
+				t.Errorf("expected commit %s, got %s", tt.commit, commit)
+			}
+		})
+	}
+}
+
+func TestClientGetDataRefNotFound(t *testing.T) {
+	base := newTestRemote(t, "test-repo", "1.0.0")
+	client := bsdata.NewClient(
+		bsdata.WithBaseURL("file://"+base),
+		bsdata.WithWorkDir(t.TempDir()),
+	)
+
+	_, _, err := client.GetDataRef(context.Background(), "test-repo", bsdata.Branch("no-such-branch"))
+	if !errors.Is(err, bsdata.ErrRefNotFound) {
+		t.Errorf("expected ErrRefNotFound for a branch, got %v", err)
+	}
+
+	_, _, err = client.GetDataRef(context.Background(), "test-repo", bsdata.Commit("0123456789012345678901234567890123456789"))
+	if !errors.Is(err, bsdata.ErrRefNotFound) {
+		t.Errorf("expected ErrRefNotFound for a commit, got %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+package bsdata
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"path"
+	"regexp"
+	"strings"
+
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// scpURL matches scp-like ssh remotes such as git@github.com:BSData/wh40k.git.
+var scpURL = regexp.MustCompile(`^[\w.-]+@[\w.-]+:`)
+
+// isRemoteURL reports whether repo is a full remote URL rather than the name
+// of a repository under the client's base URL.
+func isRemoteURL(repo string) bool {
+	return strings.Contains(repo, "://") || scpURL.MatchString(repo)
+}
+
+// remoteURL returns the URL to fetch repo from. repo may be the name of a
+// repository under the client's base URL, or any https, ssh or file URL.
+func (c *Client) remoteURL(repo string) string {
+	if isRemoteURL(repo) {
+		return repo
+	}
+
+	return c.baseURL + "/" + repo
+}
+
+// repoKey returns a name for repo that is safe to use in file names. Full
+// URLs get a hash suffix so forks of the same repository do not collide.
+func repoKey(repo string) string {
+	if !isRemoteURL(repo) {
+		return repo
+	}
+
+	name := repo
+	if i := strings.LastIndexAny(name, "/:"); i >= 0 {
+		name = name[i+1:]
+	}
+	name = strings.TrimSuffix(path.Base("/"+name), ".git")
+
+	sum := sha1.Sum([]byte(repo))
+
+	return name + "-" + hex.EncodeToString(sum[:4])
+}
+
+// WithToken authenticates HTTPS remotes with an access token, as used by
+// GitHub, GitLab and similar hosts.
+func WithToken(token string) Option {
+	return WithBasicAuth("x-access-token", token)
+}
+
+// WithBasicAuth authenticates HTTPS remotes with a username and password.
+func WithBasicAuth(username, password string) Option {
+	return WithAuth(&githttp.BasicAuth{Username: username, Password: password})
+}
+
+// WithHostKeyCallback sets how ssh host keys are verified. Use
+// ssh.NewKnownHostsCallback from go-git to check them against known_hosts
+// files other than the user's own. The callback is applied to a copy of the
+// ssh auth method given to WithAuth, so the auth method can be shared.
+func WithHostKeyCallback(cb gossh.HostKeyCallback) Option {
+	return func(c *Client) {
+		c.hostKeyCallback = cb
+	}
+}
+
+// applyHostKeyCallback replaces the client's ssh auth method, if it has
+// one, with a copy that uses the client's host key callback. The caller's
+// auth method is left alone, as it may be shared with other clients.
+func (c *Client) applyHostKeyCallback() {
+	if c.hostKeyCallback == nil {
+		return
+	}
+
+	switch a := c.auth.(type) {
+	case *ssh.PublicKeys:
+		cp := *a
+		cp.HostKeyCallback = c.hostKeyCallback
+		c.auth = &cp
+	case *ssh.PublicKeysCallback:
+		cp := *a
+		cp.HostKeyCallback = c.hostKeyCallback
+		c.auth = &cp
+	case *ssh.Password:
+		cp := *a
+		cp.HostKeyCallback = c.hostKeyCallback
+		c.auth = &cp
+	case *ssh.PasswordCallback:
+		cp := *a
+		cp.HostKeyCallback = c.hostKeyCallback
+		c.auth = &cp
+	case *ssh.KeyboardInteractive:
+		cp := *a
+		cp.HostKeyCallback = c.hostKeyCallback
+		c.auth = &cp
+	}
+}
@@ -0,0 +1,175 @@
+package bsdata_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing/transport/client"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	gossh "golang.org/x/crypto/ssh"
+
+	"github.com/myminicommission/go-bsdata"
+)
+
+func TestClientRemoteURL(t *testing.T) {
+	base := newTestRemote(t, "house-rules", "1.0.0")
+	cacheDir := t.TempDir()
+	client := bsdata.NewClient(
+		bsdata.WithWorkDir(t.TempDir()),
+		bsdata.WithCache(bsdata.CacheConfig{Dir: cacheDir}),
+	)
+
+	url := "file://" + filepath.Join(base, "house-rules")
+	catalogues, err := client.GetData(url, "1.0.0")
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if len(catalogues) != 2 {
+		t.Errorf("expected 2 catalogues, found %d", len(catalogues))
+	}
+
+	versions, err := client.ListTags(url)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if len(versions) != 1 || versions[0].Tag != "1.0.0" {
+		t.Errorf("unexpected versions %v", versions)
+	}
+
+	entries, err := os.ReadDir(cacheDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 1 || !strings.HasPrefix(entries[0].Name(), "house-rules-") {
+		t.Errorf("expected the cache to be keyed by repository name, got %v", entries)
+	}
+}
+
+func TestClientTokenAuth(t *testing.T) {
+	var user, pass string
+	var ok bool
+	rt := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		user, pass, ok = r.BasicAuth()
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+			Request:    r,
+		}, nil
+	})
+
+	client := bsdata.NewClient(
+		bsdata.WithWorkDir(t.TempDir()),
+		bsdata.WithHTTPTransport(rt),
+		bsdata.WithToken("s3cret"),
+	)
+
+	_, err := client.GetData("https://git.example.com/club/private-data.git", "")
+	if !errors.Is(err, bsdata.ErrRepoNotFound) {
+		t.Errorf("expected ErrRepoNotFound, got %v", err)
+	}
+
+	if !ok || user == "" || pass != "s3cret" {
+		t.Errorf("expected the token to be sent as basic auth, got %q:%q", user, pass)
+	}
+}
+
+func TestClientTransportReplaced(t *testing.T) {
+	var calls int
+	rt := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		calls++
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+			Request:    r,
+		}, nil
+	})
+
+	c := bsdata.NewClient(
+		bsdata.WithWorkDir(t.TempDir()),
+		bsdata.WithHTTPTransport(rt),
+	)
+
+	url := "https://git.example.com/club/private-data.git"
+	if _, err := c.ListTags(url); !errors.Is(err, bsdata.ErrRepoNotFound) {
+		t.Errorf("expected ErrRepoNotFound, got %v", err)
+	}
+
+	dispatch := client.Protocols["https"]
+	client.InstallProtocol("https", githttp.DefaultClient)
+	t.Cleanup(func() {
+		client.InstallProtocol("https", dispatch)
+	})
+
+	calls = 0
+	if _, err := c.ListTags(url); !errors.Is(err, bsdata.ErrTransportReplaced) {
+		t.Errorf("expected ErrTransportReplaced, got %v", err)
+	}
+
+	if calls != 0 {
+		t.Errorf("expected no requests through the replaced transport, got %d", calls)
+	}
+}
+
+// TestClientTransportConcurrent runs an HTTP client with its own transport
+// next to a plain client. Run it with -race to check that the shared go-git
+// protocols are never written while requests are being made.
+func TestClientTransportConcurrent(t *testing.T) {
+	base := newTestRemote(t, "test-repo", "1.0.0")
+	rt := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+			Request:    r,
+		}, nil
+	})
+
+	transportClient := bsdata.NewClient(
+		bsdata.WithWorkDir(t.TempDir()),
+		bsdata.WithHTTPTransport(rt),
+	)
+	plainClient := bsdata.NewClient(
+		bsdata.WithBaseURL("file://"+base),
+		bsdata.WithWorkDir(t.TempDir()),
+	)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		if _, err := transportClient.ListTags("https://git.example.com/club/private-data.git"); !errors.Is(err, bsdata.ErrRepoNotFound) {
+			t.Errorf("expected ErrRepoNotFound, got %v", err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		if _, err := plainClient.ListTags("test-repo"); err != nil {
+			t.Error(err)
+		}
+	}()
+	wg.Wait()
+}
+
+func TestClientHostKeyCallbackSharedAuth(t *testing.T) {
+	auth := &ssh.Password{User: "git", Password: "s3cret"}
+
+	bsdata.NewClient(
+		bsdata.WithAuth(auth),
+		bsdata.WithHostKeyCallback(gossh.InsecureIgnoreHostKey()),
+	)
+
+	if auth.HostKeyCallback != nil {
+		t.Error("expected the shared auth method to be left unchanged")
+	}
+}
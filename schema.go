@@ -0,0 +1,483 @@
+package bsdata
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// The types below model the elements shared by catalogues and game systems.
+// Each one is decoded wherever its element appears, so entries, groups,
+// modifiers and condition groups can be nested to any depth.
+
+// SelectionEntry is something a roster can select, such as a unit, a model
+// or an upgrade.
+type SelectionEntry struct {
+	Extra
+	Text          string             `xml:",chardata"`
+	ID            string             `xml:"id,attr"`
+	Name          string             `xml:"name,attr"`
+	PublicationId string             `xml:"publicationId,attr"`
+	Page          string             `xml:"page,attr"`
+	Hidden        Bool               `xml:"hidden,attr"`
+	Collective    Bool               `xml:"collective,attr"`
+	Import        Bool               `xml:"import,attr"`
+	Type          SelectionEntryType `xml:"type,attr"`
+	Modifiers     struct {
+		Extra
+		Text     string     `xml:",chardata"`
+		Modifier []Modifier `xml:"modifier"`
+	} `xml:"modifiers"`
+	ModifierGroups struct {
+		Extra
+		Text          string          `xml:",chardata"`
+		ModifierGroup []ModifierGroup `xml:"modifierGroup"`
+	} `xml:"modifierGroups"`
+	Constraints struct {
+		Extra
+		Text       string       `xml:",chardata"`
+		Constraint []Constraint `xml:"constraint"`
+	} `xml:"constraints"`
+	Profiles struct {
+		Extra
+		Text    string    `xml:",chardata"`
+		Profile []Profile `xml:"profile"`
+	} `xml:"profiles"`
+	Rules struct {
+		Extra
+		Text string `xml:",chardata"`
+		Rule []Rule `xml:"rule"`
+	} `xml:"rules"`
+	InfoLinks struct {
+		Extra
+		Text     string     `xml:",chardata"`
+		InfoLink []InfoLink `xml:"infoLink"`
+	} `xml:"infoLinks"`
+	CategoryLinks struct {
+		Extra
+		Text         string         `xml:",chardata"`
+		CategoryLink []CategoryLink `xml:"categoryLink"`
+	} `xml:"categoryLinks"`
+	SelectionEntries struct {
+		Extra
+		Text           string           `xml:",chardata"`
+		SelectionEntry []SelectionEntry `xml:"selectionEntry"`
+	} `xml:"selectionEntries"`
+	SelectionEntryGroups struct {
+		Extra
+		Text                string                `xml:",chardata"`
+		SelectionEntryGroup []SelectionEntryGroup `xml:"selectionEntryGroup"`
+	} `xml:"selectionEntryGroups"`
+	EntryLinks struct {
+		Extra
+		Text      string      `xml:",chardata"`
+		EntryLink []EntryLink `xml:"entryLink"`
+	} `xml:"entryLinks"`
+	Costs struct {
+		Extra
+		Text string `xml:",chardata"`
+		Cost []Cost `xml:"cost"`
+	} `xml:"costs"`
+}
+
+// SelectionEntryGroup groups selection entries that are chosen between,
+// such as the weapon options of a unit.
+type SelectionEntryGroup struct {
+	Extra
+	Text                    string `xml:",chardata"`
+	ID                      string `xml:"id,attr"`
+	Name                    string `xml:"name,attr"`
+	PublicationId           string `xml:"publicationId,attr"`
+	Page                    string `xml:"page,attr"`
+	Hidden                  Bool   `xml:"hidden,attr"`
+	Collective              Bool   `xml:"collective,attr"`
+	Import                  Bool   `xml:"import,attr"`
+	DefaultSelectionEntryId string `xml:"defaultSelectionEntryId,attr"`
+	Modifiers               struct {
+		Extra
+		Text     string     `xml:",chardata"`
+		Modifier []Modifier `xml:"modifier"`
+	} `xml:"modifiers"`
+	ModifierGroups struct {
+		Extra
+		Text          string          `xml:",chardata"`
+		ModifierGroup []ModifierGroup `xml:"modifierGroup"`
+	} `xml:"modifierGroups"`
+	Constraints struct {
+		Extra
+		Text       string       `xml:",chardata"`
+		Constraint []Constraint `xml:"constraint"`
+	} `xml:"constraints"`
+	Profiles struct {
+		Extra
+		Text    string    `xml:",chardata"`
+		Profile []Profile `xml:"profile"`
+	} `xml:"profiles"`
+	Rules struct {
+		Extra
+		Text string `xml:",chardata"`
+		Rule []Rule `xml:"rule"`
+	} `xml:"rules"`
+	InfoLinks struct {
+		Extra
+		Text     string     `xml:",chardata"`
+		InfoLink []InfoLink `xml:"infoLink"`
+	} `xml:"infoLinks"`
+	CategoryLinks struct {
+		Extra
+		Text         string         `xml:",chardata"`
+		CategoryLink []CategoryLink `xml:"categoryLink"`
+	} `xml:"categoryLinks"`
+	SelectionEntries struct {
+		Extra
+		Text           string           `xml:",chardata"`
+		SelectionEntry []SelectionEntry `xml:"selectionEntry"`
+	} `xml:"selectionEntries"`
+	SelectionEntryGroups struct {
+		Extra
+		Text                string                `xml:",chardata"`
+		SelectionEntryGroup []SelectionEntryGroup `xml:"selectionEntryGroup"`
+	} `xml:"selectionEntryGroups"`
+	EntryLinks struct {
+		Extra
+		Text      string      `xml:",chardata"`
+		EntryLink []EntryLink `xml:"entryLink"`
+	} `xml:"entryLinks"`
+}
+
+// EntryLink includes the shared selection entry or selection entry group
+// with the ID TargetId, adjusted by its own modifiers and constraints.
+type EntryLink struct {
+	Extra
+	Text          string        `xml:",chardata"`
+	ID            string        `xml:"id,attr"`
+	Name          string        `xml:"name,attr"`
+	PublicationId string        `xml:"publicationId,attr"`
+	Page          string        `xml:"page,attr"`
+	Hidden        Bool          `xml:"hidden,attr"`
+	Collective    Bool          `xml:"collective,attr"`
+	Import        Bool          `xml:"import,attr"`
+	TargetId      string        `xml:"targetId,attr"`
+	Type          EntryLinkType `xml:"type,attr"`
+	Modifiers     struct {
+		Extra
+		Text     string     `xml:",chardata"`
+		Modifier []Modifier `xml:"modifier"`
+	} `xml:"modifiers"`
+	ModifierGroups struct {
+		Extra
+		Text          string          `xml:",chardata"`
+		ModifierGroup []ModifierGroup `xml:"modifierGroup"`
+	} `xml:"modifierGroups"`
+	Constraints struct {
+		Extra
+		Text       string       `xml:",chardata"`
+		Constraint []Constraint `xml:"constraint"`
+	} `xml:"constraints"`
+	Profiles struct {
+		Extra
+		Text    string    `xml:",chardata"`
+		Profile []Profile `xml:"profile"`
+	} `xml:"profiles"`
+	Rules struct {
+		Extra
+		Text string `xml:",chardata"`
+		Rule []Rule `xml:"rule"`
+	} `xml:"rules"`
+	InfoLinks struct {
+		Extra
+		Text     string     `xml:",chardata"`
+		InfoLink []InfoLink `xml:"infoLink"`
+	} `xml:"infoLinks"`
+	CategoryLinks struct {
+		Extra
+		Text         string         `xml:",chardata"`
+		CategoryLink []CategoryLink `xml:"categoryLink"`
+	} `xml:"categoryLinks"`
+	SelectionEntries struct {
+		Extra
+		Text           string           `xml:",chardata"`
+		SelectionEntry []SelectionEntry `xml:"selectionEntry"`
+	} `xml:"selectionEntries"`
+	SelectionEntryGroups struct {
+		Extra
+		Text                string                `xml:",chardata"`
+		SelectionEntryGroup []SelectionEntryGroup `xml:"selectionEntryGroup"`
+	} `xml:"selectionEntryGroups"`
+	EntryLinks struct {
+		Extra
+		Text      string      `xml:",chardata"`
+		EntryLink []EntryLink `xml:"entryLink"`
+	} `xml:"entryLinks"`
+	Costs struct {
+		Extra
+		Text string `xml:",chardata"`
+		Cost []Cost `xml:"cost"`
+	} `xml:"costs"`
+}
+
+// Modifier changes the Field of its parent, such as a cost or a
+// constraint, when its conditions hold.
+type Modifier struct {
+	Extra
+	Text  string       `xml:",chardata"`
+	Type  ModifierType `xml:"type,attr"`
+	Field string       `xml:"field,attr"`
+	// Value is kept as text because modifiers can set names and
+	// characteristics as well as numbers; see FloatValue.
+	Value   string `xml:"value,attr"`
+	Repeats struct {
+		Extra
+		Text   string   `xml:",chardata"`
+		Repeat []Repeat `xml:"repeat"`
+	} `xml:"repeats"`
+	Conditions struct {
+		Extra
+		Text      string      `xml:",chardata"`
+		Condition []Condition `xml:"condition"`
+	} `xml:"conditions"`
+	ConditionGroups struct {
+		Extra
+		Text           string           `xml:",chardata"`
+		ConditionGroup []ConditionGroup `xml:"conditionGroup"`
+	} `xml:"conditionGroups"`
+}
+
+// FloatValue returns Value as a number, for modifiers of numeric fields
+// such as costs and constraints.
+func (m Modifier) FloatValue() (float64, error) {
+	v, err := strconv.ParseFloat(m.Value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("bsdata: modifier value %q is not a number", m.Value)
+	}
+
+	return v, nil
+}
+
+// ModifierGroup applies its modifiers and nested groups when its own
+// conditions hold.
+type ModifierGroup struct {
+	Extra
+	Text      string `xml:",chardata"`
+	Modifiers struct {
+		Extra
+		Text     string     `xml:",chardata"`
+		Modifier []Modifier `xml:"modifier"`
+	} `xml:"modifiers"`
+	ModifierGroups struct {
+		Extra
+		Text          string          `xml:",chardata"`
+		ModifierGroup []ModifierGroup `xml:"modifierGroup"`
+	} `xml:"modifierGroups"`
+	Repeats struct {
+		Extra
+		Text   string   `xml:",chardata"`
+		Repeat []Repeat `xml:"repeat"`
+	} `xml:"repeats"`
+	Conditions struct {
+		Extra
+		Text      string      `xml:",chardata"`
+		Condition []Condition `xml:"condition"`
+	} `xml:"conditions"`
+	ConditionGroups struct {
+		Extra
+		Text           string           `xml:",chardata"`
+		ConditionGroup []ConditionGroup `xml:"conditionGroup"`
+	} `xml:"conditionGroups"`
+}
+
+// Condition compares the Field of the selections or forces in Scope that
+// match ChildId against Value.
+type Condition struct {
+	Extra
+	Text                   string        `xml:",chardata"`
+	Field                  string        `xml:"field,attr"`
+	Scope                  Scope         `xml:"scope,attr"`
+	Value                  Float         `xml:"value,attr"`
+	PercentValue           Bool          `xml:"percentValue,attr"`
+	Shared                 Bool          `xml:"shared,attr"`
+	IncludeChildSelections Bool          `xml:"includeChildSelections,attr"`
+	IncludeChildForces     Bool          `xml:"includeChildForces,attr"`
+	ChildId                string        `xml:"childId,attr"`
+	Type                   ConditionType `xml:"type,attr"`
+}
+
+// ConditionGroup combines conditions and nested groups with "and" or "or",
+// as given by Type.
+type ConditionGroup struct {
+	Extra
+	Text       string             `xml:",chardata"`
+	Type       ConditionGroupType `xml:"type,attr"`
+	Conditions struct {
+		Extra
+		Text      string      `xml:",chardata"`
+		Condition []Condition `xml:"condition"`
+	} `xml:"conditions"`
+	ConditionGroups struct {
+		Extra
+		Text           string           `xml:",chardata"`
+		ConditionGroup []ConditionGroup `xml:"conditionGroup"`
+	} `xml:"conditionGroups"`
+}
+
+// Constraint limits the Field of its parent within Scope, such as the
+// number of times an entry can be selected.
+type Constraint struct {
+	Extra
+	Text                   string         `xml:",chardata"`
+	ID                     string         `xml:"id,attr"`
+	Field                  string         `xml:"field,attr"`
+	Scope                  Scope          `xml:"scope,attr"`
+	Value                  Float          `xml:"value,attr"`
+	PercentValue           Bool           `xml:"percentValue,attr"`
+	Shared                 Bool           `xml:"shared,attr"`
+	IncludeChildSelections Bool           `xml:"includeChildSelections,attr"`
+	IncludeChildForces     Bool           `xml:"includeChildForces,attr"`
+	Type                   ConstraintType `xml:"type,attr"`
+}
+
+// Repeat applies its modifier once for every Value of Field counted in
+// Scope.
+type Repeat struct {
+	Extra
+	Text                   string `xml:",chardata"`
+	Field                  string `xml:"field,attr"`
+	Scope                  Scope  `xml:"scope,attr"`
+	Value                  Float  `xml:"value,attr"`
+	PercentValue           Bool   `xml:"percentValue,attr"`
+	Shared                 Bool   `xml:"shared,attr"`
+	IncludeChildSelections Bool   `xml:"includeChildSelections,attr"`
+	IncludeChildForces     Bool   `xml:"includeChildForces,attr"`
+	ChildId                string `xml:"childId,attr"`
+	Repeats                Int    `xml:"repeats,attr"`
+	RoundUp                Bool   `xml:"roundUp,attr"`
+}
+
+// Profile is a set of characteristics, such as a unit's statline, of the
+// profile type TypeId.
+type Profile struct {
+	Extra
+	Text          string `xml:",chardata"`
+	ID            string `xml:"id,attr"`
+	Name          string `xml:"name,attr"`
+	PublicationId string `xml:"publicationId,attr"`
+	Page          string `xml:"page,attr"`
+	Hidden        Bool   `xml:"hidden,attr"`
+	TypeId        string `xml:"typeId,attr"`
+	TypeName      string `xml:"typeName,attr"`
+	Modifiers     struct {
+		Extra
+		Text     string     `xml:",chardata"`
+		Modifier []Modifier `xml:"modifier"`
+	} `xml:"modifiers"`
+	ModifierGroups struct {
+		Extra
+		Text          string          `xml:",chardata"`
+		ModifierGroup []ModifierGroup `xml:"modifierGroup"`
+	} `xml:"modifierGroups"`
+	Characteristics struct {
+		Extra
+		Text           string `xml:",chardata"`
+		Characteristic []struct {
+			Extra
+			// Text is the value of the characteristic.
+			Text   string `xml:",chardata"`
+			Name   string `xml:"name,attr"`
+			TypeId string `xml:"typeId,attr"`
+		} `xml:"characteristic"`
+	} `xml:"characteristics"`
+}
+
+// Rule is a named piece of rules text.
+type Rule struct {
+	Extra
+	Text          string `xml:",chardata"`
+	ID            string `xml:"id,attr"`
+	Name          string `xml:"name,attr"`
+	PublicationId string `xml:"publicationId,attr"`
+	Page          string `xml:"page,attr"`
+	Hidden        Bool   `xml:"hidden,attr"`
+	Description   string `xml:"description"`
+	Modifiers     struct {
+		Extra
+		Text     string     `xml:",chardata"`
+		Modifier []Modifier `xml:"modifier"`
+	} `xml:"modifiers"`
+	ModifierGroups struct {
+		Extra
+		Text          string          `xml:",chardata"`
+		ModifierGroup []ModifierGroup `xml:"modifierGroup"`
+	} `xml:"modifierGroups"`
+}
+
+// Cost is the cost of a selection in the cost type TypeId.
+type Cost struct {
+	Extra
+	Text   string `xml:",chardata"`
+	Name   string `xml:"name,attr"`
+	TypeId string `xml:"typeId,attr"`
+	Value  Float  `xml:"value,attr"`
+}
+
+// InfoLink includes the shared profile, rule or info group with the ID
+// TargetId.
+type InfoLink struct {
+	Extra
+	Text          string `xml:",chardata"`
+	ID            string `xml:"id,attr"`
+	Name          string `xml:"name,attr"`
+	PublicationId string `xml:"publicationId,attr"`
+	Page          string `xml:"page,attr"`
+	Hidden        Bool   `xml:"hidden,attr"`
+	TargetId      string `xml:"targetId,attr"`
+	Type          string `xml:"type,attr"`
+	Modifiers     struct {
+		Extra
+		Text     string     `xml:",chardata"`
+		Modifier []Modifier `xml:"modifier"`
+	} `xml:"modifiers"`
+	ModifierGroups struct {
+		Extra
+		Text          string          `xml:",chardata"`
+		ModifierGroup []ModifierGroup `xml:"modifierGroup"`
+	} `xml:"modifierGroups"`
+}
+
+// CategoryLink places its parent in the category entry with the ID
+// TargetId.
+type CategoryLink struct {
+	Extra
+	Text      string `xml:",chardata"`
+	ID        string `xml:"id,attr"`
+	Name      string `xml:"name,attr"`
+	Hidden    Bool   `xml:"hidden,attr"`
+	TargetId  string `xml:"targetId,attr"`
+	Primary   Bool   `xml:"primary,attr"`
+	Modifiers struct {
+		Extra
+		Text     string     `xml:",chardata"`
+		Modifier []Modifier `xml:"modifier"`
+	} `xml:"modifiers"`
+	ModifierGroups struct {
+		Extra
+		Text          string          `xml:",chardata"`
+		ModifierGroup []ModifierGroup `xml:"modifierGroup"`
+	} `xml:"modifierGroups"`
+	Constraints struct {
+		Extra
+		Text       string       `xml:",chardata"`
+		Constraint []Constraint `xml:"constraint"`
+	} `xml:"constraints"`
+}
+
+// CatalogueLink makes the catalogue with the ID TargetId available to its
+// parent catalogue. With ImportRootEntries set, the target's root entries
+// are also offered as if they were the parent's own.
+type CatalogueLink struct {
+	Extra
+	Text              string `xml:",chardata"`
+	ID                string `xml:"id,attr"`
+	Name              string `xml:"name,attr"`
+	TargetId          string `xml:"targetId,attr"`
+	Type              string `xml:"type,attr"`
+	ImportRootEntries Bool   `xml:"importRootEntries,attr"`
+}
@@ -0,0 +1,111 @@
+package bsdata_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/myminicommission/go-bsdata"
+)
+
+const nestedCatalogue = `<?xml version="1.0" encoding="UTF-8"?>
+<catalogue id="cat-n" name="Nested" gameSystemId="gst-1">
+  <sharedSelectionEntries>
+    <selectionEntry id="e1" name="Level 1" type="unit">
+      <selectionEntries>
+        <selectionEntry id="e2" name="Level 2" type="model">
+          <selectionEntryGroups>
+            <selectionEntryGroup id="g3" name="Level 3">
+              <selectionEntries>
+                <selectionEntry id="e4" name="Level 4" type="upgrade">
+                  <selectionEntries>
+                    <selectionEntry id="e5" name="Level 5" type="upgrade">
+                      <costs>
+                        <cost name="pts" typeId="pts" value="5"/>
+                      </costs>
+                    </selectionEntry>
+                  </selectionEntries>
+                </selectionEntry>
+              </selectionEntries>
+            </selectionEntryGroup>
+          </selectionEntryGroups>
+        </selectionEntry>
+      </selectionEntries>
+      <modifiers>
+        <modifier type="increment" field="pts" value="1">
+          <conditions>
+            <condition field="selections" scope="roster" value="1" childId="e2" type="atLeast"/>
+            <condition field="selections" scope="force" value="2" childId="e4" type="atMost"/>
+          </conditions>
+          <conditionGroups>
+            <conditionGroup type="or">
+              <conditionGroups>
+                <conditionGroup type="and">
+                  <conditions>
+                    <condition field="selections" scope="parent" value="0" childId="e5" type="greaterThan"/>
+                  </conditions>
+                </conditionGroup>
+              </conditionGroups>
+            </conditionGroup>
+          </conditionGroups>
+        </modifier>
+      </modifiers>
+    </selectionEntry>
+  </sharedSelectionEntries>
+</catalogue>`
+
+func TestParseCatalogueNesting(t *testing.T) {
+	cat, err := bsdata.ParseCatalogue(strings.NewReader(nestedCatalogue))
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	entry := cat.SharedSelectionEntries.SelectionEntry[0]
+	l2 := entry.SelectionEntries.SelectionEntry[0]
+	l3 := l2.SelectionEntryGroups.SelectionEntryGroup[0]
+	l4 := l3.SelectionEntries.SelectionEntry[0]
+	if len(l4.SelectionEntries.SelectionEntry) != 1 {
+		t.Errorf("expected the fifth level entry to be decoded")
+		t.FailNow()
+	}
+
+	l5 := l4.SelectionEntries.SelectionEntry[0]
+	if l5.Name != "Level 5" || len(l5.Costs.Cost) != 1 || l5.Costs.Cost[0].Value != 5 {
+		t.Errorf("unexpected fifth level entry %+v", l5)
+	}
+
+	modifier := entry.Modifiers.Modifier[0]
+	if len(modifier.Conditions.Condition) != 2 {
+		t.Errorf("expected 2 conditions, found %d", len(modifier.Conditions.Condition))
+	}
+
+	nested := modifier.ConditionGroups.ConditionGroup[0].ConditionGroups.ConditionGroup
+	if len(nested) != 1 || nested[0].Conditions.Condition[0].ChildId != "e5" {
+		t.Errorf("expected the nested condition group to be decoded, got %+v", nested)
+	}
+}
+
+// countEntries walks entries and every entry nested below them.
+func countEntries(entries []bsdata.SelectionEntry) int {
+	n := len(entries)
+	for _, e := range entries {
+		n += countEntries(e.SelectionEntries.SelectionEntry)
+		for _, g := range e.SelectionEntryGroups.SelectionEntryGroup {
+			n += countEntries(g.SelectionEntries.SelectionEntry)
+		}
+	}
+
+	return n
+}
+
+func TestSelectionEntryNamedType(t *testing.T) {
+	cat, err := bsdata.ParseCatalogue(strings.NewReader(nestedCatalogue))
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if n := countEntries(cat.SharedSelectionEntries.SelectionEntry); n != 4 {
+		t.Errorf("expected 4 selection entries, found %d", n)
+	}
+}
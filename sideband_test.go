@@ -0,0 +1,36 @@
+package bsdata
+
+import "testing"
+
+func TestSidebandWriter(t *testing.T) {
+	var events []Progress
+	w := newProgressReporter(func(p Progress) {
+		events = append(events, p)
+	}).sideband()
+
+	lines := "remote: Counting objects:  50% (5/10)\r" +
+		"Receiving objects: 100% (10/10), 2.50 KiB | 1.20 MiB/s, done.\n" +
+		"Receiving objects: 100% (10/10), 612 bytes, done.\n" +
+		"Resolving deltas: 100% (3/3), done.\n"
+	if _, err := w.Write([]byte(lines)); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Progress{
+		{Phase: PhaseCounting, Current: 5, Total: 10},
+		{Phase: PhaseReceiving, Current: 10, Total: 10, Bytes: 2560},
+		{Phase: PhaseReceiving, Current: 10, Total: 10, Bytes: 612},
+		{Phase: PhaseResolving, Current: 3, Total: 3},
+	}
+	if len(events) != len(want) {
+		t.Errorf("expected %d events, got %d", len(want), len(events))
+		t.FailNow()
+	}
+
+	for i, ev := range events {
+		ev.Message = ""
+		if ev != want[i] {
+			t.Errorf("line %d: expected %+v, got %+v", i, want[i], ev)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package bsdata
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"time"
+)
+
+const (
+	// manifestName is the name of the manifest in a snapshot bundle. It
+	// does not have a data file extension, so discovery never picks it up.
+	manifestName = "manifest.json"
+	// snapshotFormat is the version of the bundle layout written by
+	// ExportSnapshot.
+	snapshotFormat = 1
+)
+
+// manifest describes the contents of a snapshot bundle.
+type manifest struct {
+	Format    int            `json:"format"`
+	Repo      string         `json:"repo"`
+	RefKind   string         `json:"refKind"`
+	RefName   string         `json:"refName,omitempty"`
+	Commit    string         `json:"commit,omitempty"`
+	FetchedAt time.Time      `json:"fetchedAt"`
+	Files     []manifestFile `json:"files"`
+}
+
+// manifestFile is a data file held in a snapshot bundle.
+type manifestFile struct {
+	Path   string   `json:"path"`
+	Size   int64    `json:"size"`
+	Type   FileType `json:"type"`
+	SHA256 string   `json:"sha256"`
+}
+
+var refKindNames = map[RefKind]string{
+	RefHead:   "head",
+	RefTag:    "tag",
+	RefBranch: "branch",
+	RefCommit: "commit",
+}
+
+func (m *manifest) ref() (Ref, error) {
+	for kind, name := range refKindNames {
+		if name == m.RefKind {
+			return Ref{Kind: kind, Name: m.RefName}, nil
+		}
+	}
+
+	return Ref{}, fmt.Errorf("bsdata: snapshot has unknown ref kind %q", m.RefKind)
+}
+
+// ExportSnapshot fetches repo at ref and writes it to w as a snapshot
+// bundle that LoadSnapshot can read without network access.
+func ExportSnapshot(ctx context.Context, repo string, ref Ref, w io.Writer) error {
+	return defaultClient.ExportSnapshot(ctx, repo, ref, w)
+}
+
+// LoadSnapshot reads the snapshot bundle at path, as written by
+// ExportSnapshot. It never touches git or the network.
+func LoadSnapshot(path string) (*Dataset, error) {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	return readSnapshot(&zr.Reader)
+}
+
+// ReadSnapshot reads a snapshot bundle of the given size from r, as
+// written by ExportSnapshot.
+func ReadSnapshot(r io.ReaderAt, size int64) (*Dataset, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	return readSnapshot(zr)
+}
+
+// ExportSnapshot fetches repo at ref and writes it to w as a snapshot
+// bundle: a zip archive holding the data files exactly as stored in the
+// repository, next to a manifest recording the repo, ref, commit, fetch
+// time and the hash of every file. The client's discovery options select
+// the files that are bundled. Loading the bundle with LoadSnapshot gives
+// the same Dataset as GetDataset did when the bundle was exported.
+func (c *Client) ExportSnapshot(ctx context.Context, repo string, ref Ref, w io.Writer) error {
+	c.logger.Info("exporting snapshot", "repo", repo, "ref", ref.String())
+
+	return c.withCheckout(ctx, repo, ref, func(fsys fs.FS, commit string) error {
+		m := manifest{
+			Format:    snapshotFormat,
+			Repo:      repo,
+			RefKind:   refKindNames[ref.Kind],
+			RefName:   ref.Name,
+			Commit:    commit,
+			FetchedAt: time.Now().UTC(),
+		}
+
+		files, err := discover(fsys, c.discovery, c.logger)
+		if err != nil {
+			return err
+		}
+
+		zw := zip.NewWriter(w)
+		for _, f := range files {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			b, err := fs.ReadFile(fsys, f.Path)
+			if err != nil {
+				return err
+			}
+
+			if err := writeZipFile(zw, f.Path, m.FetchedAt, b); err != nil {
+				return err
+			}
+
+			sum := sha256.Sum256(b)
+			m.Files = append(m.Files, manifestFile{
+				Path:   f.Path,
+				Size:   int64(len(b)),
+				Type:   f.Type,
+				SHA256: hex.EncodeToString(sum[:]),
+			})
+		}
+
+		b, err := json.MarshalIndent(m, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		if err := writeZipFile(zw, manifestName, m.FetchedAt, b); err != nil {
+			return err
+		}
+
+		return zw.Close()
+	})
+}
+
+func writeZipFile(zw *zip.Writer, name string, modified time.Time, b []byte) error {
+	w, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: modified,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(b)
+	return err
+}
+
+// readSnapshot loads the data files in the bundle zr and checks them
+// against its manifest.
+func readSnapshot(zr *zip.Reader) (*Dataset, error) {
+	b, err := fs.ReadFile(zr, manifestName)
+	if err != nil {
+		return nil, fmt.Errorf("bsdata: reading snapshot manifest: %w", err)
+	}
+
+	var m manifest
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("bsdata: reading snapshot manifest: %w", err)
+	}
+
+	if m.Format != snapshotFormat {
+		return nil, fmt.Errorf("bsdata: unsupported snapshot format %d", m.Format)
+	}
+
+	ref, err := m.ref()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.verify(zr); err != nil {
+		return nil, err
+	}
+
+	// the bundle now holds exactly the files that were selected on export
+	l := defaultLoader()
+	l.discovery = DiscoverOptions{Recursive: true}
+	ds, err := l.load(context.Background(), zr)
+	if ds == nil {
+		return nil, err
+	}
+
+	ds.Repo = m.Repo
+	ds.Ref = ref
+	ds.Commit = m.Commit
+	ds.FetchedAt = m.FetchedAt
+
+	return ds, err
+}
+
+// verify checks that the bundle zr holds exactly the files the manifest
+// lists, each with the content it was exported with.
+func (m *manifest) verify(zr *zip.Reader) error {
+	listed := map[string]bool{manifestName: true}
+	for _, f := range m.Files {
+		listed[f.Path] = true
+
+		b, err := fs.ReadFile(zr, f.Path)
+		if errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("bsdata: snapshot is missing %s", f.Path)
+		}
+		if err != nil {
+			return err
+		}
+
+		sum := sha256.Sum256(b)
+		if hex.EncodeToString(sum[:]) != f.SHA256 {
+			return fmt.Errorf("bsdata: snapshot file %s does not match its manifest", f.Path)
+		}
+	}
+
+	for _, f := range zr.File {
+		if !f.FileInfo().IsDir() && !listed[f.Name] {
+			return fmt.Errorf("bsdata: snapshot file %s is not listed in its manifest", f.Name)
+		}
+	}
+
+	return nil
+}
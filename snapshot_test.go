@@ -0,0 +1,166 @@
+package bsdata_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/myminicommission/go-bsdata"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	base := newTestRemote(t, "test-repo", "1.0.0")
+	client := bsdata.NewClient(
+		bsdata.WithBaseURL("file://"+base),
+		bsdata.WithWorkDir(t.TempDir()),
+	)
+
+	path := filepath.Join(t.TempDir(), "test-repo.zip")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.ExportSnapshot(context.Background(), "test-repo", bsdata.Tag("1.0.0"), f); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	live, err := client.GetDataset(context.Background(), "test-repo", bsdata.Tag("1.0.0"))
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	ds, err := bsdata.LoadSnapshot(path)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if ds.Repo != live.Repo || ds.Ref != live.Ref || ds.Commit != live.Commit {
+		t.Errorf("expected %s %v %s, got %s %v %s", live.Repo, live.Ref, live.Commit, ds.Repo, ds.Ref, ds.Commit)
+	}
+
+	if ds.FetchedAt.IsZero() {
+		t.Error("expected the fetch time to be recorded")
+	}
+
+	if ds.GameSystem == nil || ds.GameSystem.Source != live.GameSystem.Source {
+		t.Errorf("expected game system %+v, got %+v", live.GameSystem, ds.GameSystem)
+	}
+
+	if len(ds.Catalogues) != len(live.Catalogues) {
+		t.Errorf("expected %d catalogues, found %d", len(live.Catalogues), len(ds.Catalogues))
+		t.FailNow()
+	}
+	for i, cat := range ds.Catalogues {
+		if cat.Source != live.Catalogues[i].Source || cat.Name != live.Catalogues[i].Name {
+			t.Errorf("expected catalogue %s %+v, got %s %+v", live.Catalogues[i].Name, live.Catalogues[i].Source, cat.Name, cat.Source)
+		}
+	}
+}
+
+func TestReadSnapshotTampered(t *testing.T) {
+	base := newTestRemote(t, "test-repo", "1.0.0")
+	client := bsdata.NewClient(
+		bsdata.WithBaseURL("file://"+base),
+		bsdata.WithWorkDir(t.TempDir()),
+	)
+
+	var buf bytes.Buffer
+	if err := client.ExportSnapshot(context.Background(), "test-repo", bsdata.Tag("1.0.0"), &buf); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	tests := []struct {
+		name  string
+		edit  func(name string, b []byte) ([]byte, bool)
+		extra string
+	}{
+		{
+			name: "modified",
+			edit: func(name string, b []byte) ([]byte, bool) {
+				if name == "Test-Faction.cat" {
+					b = bytes.Replace(b, []byte("Test Faction"), []byte("Changed Faction"), 1)
+				}
+				return b, true
+			},
+		},
+		{
+			name: "missing",
+			edit: func(name string, b []byte) ([]byte, bool) {
+				return b, name != "Test-Faction.cat"
+			},
+		},
+		{
+			name: "unlisted",
+			edit: func(name string, b []byte) ([]byte, bool) {
+				return b, true
+			},
+			extra: "Extra.cat",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// rewrite the bundle with the edited files but the original manifest
+			b := rewriteZip(t, buf.Bytes(), tt.edit, tt.extra)
+
+			_, err := bsdata.ReadSnapshot(bytes.NewReader(b), int64(len(b)))
+			if err == nil {
+				t.Error("expected an error for a bundle that does not match the manifest")
+			}
+		})
+	}
+}
+
+// rewriteZip copies the archive b, passing each file through edit and
+// dropping those it does not keep, and adds an empty file named extra.
+func rewriteZip(t *testing.T, b []byte, edit func(name string, b []byte) ([]byte, bool), extra string) []byte {
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	zw := zip.NewWriter(&out)
+	write := func(name string, b []byte) {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, f := range zr.File {
+		r, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b, keep := edit(f.Name, b); keep {
+			write(f.Name, b)
+		}
+	}
+	if extra != "" {
+		write(extra, nil)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return out.Bytes()
+}
@@ -0,0 +1,132 @@
+package bsdata
+
+import (
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Extra holds the XML attributes and child elements of an element that
+// the model has no field for, so that decoding loses nothing. Every type
+// decoded from a data file embeds it.
+type Extra struct {
+	UnknownAttrs    []xml.Attr       `xml:",any,attr"`
+	UnknownElements []UnknownElement `xml:",any"`
+}
+
+// UnknownElement is an element captured by Extra, kept verbatim.
+type UnknownElement struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr `xml:",any,attr"`
+	InnerXML string     `xml:",innerxml"`
+}
+
+// Unconsumed is an attribute or element that was captured by Extra rather
+// than decoded into a field.
+type Unconsumed struct {
+	// Path locates the parent element, such as
+	// "catalogue/sharedSelectionEntries/selectionEntry[0]".
+	Path string
+	// Name is the name of the attribute or element.
+	Name string
+	// Attr is true for attributes and false for elements.
+	Attr bool
+}
+
+func (u Unconsumed) String() string {
+	kind := "element"
+	if u.Attr {
+		kind = "attribute"
+	}
+
+	return fmt.Sprintf("%s: unknown %s %s", u.Path, kind, u.Name)
+}
+
+// WithStrict makes the client reject data files holding XML that the model
+// does not decode into a field. Each such file fails with a *StrictError
+// listing what was not consumed. By default the unknown XML is kept in the
+// Extra fields and loading carries on.
+func WithStrict() Option {
+	return func(c *Client) {
+		c.strict = true
+	}
+}
+
+// FindUnconsumed lists the XML captured by the Extra fields anywhere in v,
+// which is usually a *Catalogue or a *GameSystem. An empty result means
+// the model decoded the whole file.
+func FindUnconsumed(v interface{}) []Unconsumed {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+
+	var found []Unconsumed
+	walkUnconsumed(rv, elementName(rv), &found)
+
+	return found
+}
+
+var extraType = reflect.TypeOf(Extra{})
+
+func walkUnconsumed(v reflect.Value, path string, found *[]Unconsumed) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			walkUnconsumed(v.Elem(), path, found)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			walkUnconsumed(v.Index(i), fmt.Sprintf("%s[%d]", path, i), found)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.Type == extraType {
+				v.Field(i).Interface().(Extra).report(path, found)
+				continue
+			}
+
+			// only descend into child elements, not into attributes, text
+			// or fields that are not part of the XML
+			tag := f.Tag.Get("xml")
+			name := strings.Split(tag, ",")[0]
+			if f.PkgPath != "" || tag == "-" || name == "" || strings.Contains(tag, ",attr") {
+				continue
+			}
+
+			walkUnconsumed(v.Field(i), path+"/"+name, found)
+		}
+	}
+}
+
+func (e Extra) report(path string, found *[]Unconsumed) {
+	for _, attr := range e.UnknownAttrs {
+		// namespace declarations are not data
+		if attr.Name.Space == "xmlns" || (attr.Name.Space == "" && attr.Name.Local == "xmlns") {
+			continue
+		}
+
+		*found = append(*found, Unconsumed{Path: path, Name: attr.Name.Local, Attr: true})
+	}
+
+	for _, el := range e.UnknownElements {
+		*found = append(*found, Unconsumed{Path: path, Name: el.XMLName.Local})
+	}
+}
+
+// elementName returns the element name in the XMLName tag of the struct v,
+// if it has one.
+func elementName(v reflect.Value) string {
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+
+	if f, ok := v.Type().FieldByName("XMLName"); ok {
+		return strings.Split(f.Tag.Get("xml"), ",")[0]
+	}
+
+	return ""
+}
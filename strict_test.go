@@ -0,0 +1,86 @@
+package bsdata_test
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/myminicommission/go-bsdata"
+)
+
+const unknownCatalogue = `<catalogue id="cat-u" name="Unknown" gameSystemId="gst-1" flavour="extra">
+  <profileTypes>
+    <profileType id="pt-1" name="Unit"/>
+    <profileType id="pt-2" name="Weapon"/>
+  </profileTypes>
+  <sharedSelectionEntries>
+    <selectionEntry id="e1" name="Entry" type="unit" sortIndex="3">
+      <infoGroups>
+        <infoGroup id="ig-1" name="Notes"/>
+      </infoGroups>
+    </selectionEntry>
+  </sharedSelectionEntries>
+</catalogue>`
+
+func TestParseCatalogueCapturesUnknown(t *testing.T) {
+	cat, err := bsdata.ParseCatalogue(strings.NewReader(unknownCatalogue))
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if len(cat.ProfileTypes.ProfileType) != 2 {
+		t.Errorf("expected 2 profile types, found %d", len(cat.ProfileTypes.ProfileType))
+	}
+
+	entry := cat.SharedSelectionEntries.SelectionEntry[0]
+	if len(entry.UnknownElements) != 1 || !strings.Contains(entry.UnknownElements[0].InnerXML, `id="ig-1"`) {
+		t.Errorf("expected the infoGroups element to be kept, got %+v", entry.UnknownElements)
+	}
+
+	expected := []string{
+		"catalogue: unknown attribute flavour",
+		"catalogue/sharedSelectionEntries/selectionEntry[0]: unknown attribute sortIndex",
+		"catalogue/sharedSelectionEntries/selectionEntry[0]: unknown element infoGroups",
+	}
+	unconsumed := bsdata.FindUnconsumed(cat)
+	if len(unconsumed) != len(expected) {
+		t.Errorf("expected %v, got %v", expected, unconsumed)
+		t.FailNow()
+	}
+	for i, u := range unconsumed {
+		if u.String() != expected[i] {
+			t.Errorf("expected %q, got %q", expected[i], u.String())
+		}
+	}
+}
+
+func TestClientStrict(t *testing.T) {
+	fsys := fstest.MapFS{
+		"Test-Faction.cat": &fstest.MapFile{Data: readFixture(t, "Test-Faction.cat")},
+		"Unknown.cat":      &fstest.MapFile{Data: []byte(unknownCatalogue)},
+	}
+
+	catalogues, err := bsdata.NewClient(bsdata.WithStrict()).LoadFS(fsys)
+	var serr *bsdata.StrictError
+	if !errors.As(err, &serr) {
+		t.Errorf("expected a StrictError, got %v", err)
+		t.FailNow()
+	}
+
+	if serr.File != "Unknown.cat" || len(serr.Unconsumed) != 3 {
+		t.Errorf("unexpected report for %s: %v", serr.File, serr.Unconsumed)
+	}
+
+	if len(catalogues) != 1 || catalogues[0].ID != "cat-1" {
+		t.Errorf("expected only the fully modelled catalogue, got %d", len(catalogues))
+	}
+}
+
+func TestFixturesFullyConsumed(t *testing.T) {
+	if _, err := bsdata.NewClient(bsdata.WithStrict()).LoadFS(os.DirFS("testdata/local")); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,88 @@
+package bsdata
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/client"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+)
+
+// go-git only lets a process install one transport per URL scheme, so
+// clients with their own HTTP transport smuggle it through the auth method
+// to a dispatching transport installed for http and https. It is installed
+// here, before any client can make a request, as client.Protocols is a
+// plain map that go-git reads without locking.
+func init() {
+	for _, scheme := range []string{"http", "https"} {
+		client.InstallProtocol(scheme, &dispatchTransport{fallback: client.Protocols[scheme]})
+	}
+}
+
+// transportAuth wraps a client's credentials together with the HTTP client
+// that should carry them.
+type transportAuth struct {
+	transport.AuthMethod
+	client *http.Client
+}
+
+func (a *transportAuth) Name() string {
+	if a.AuthMethod == nil {
+		return "bsdata-transport"
+	}
+
+	return a.AuthMethod.Name()
+}
+
+func (a *transportAuth) String() string {
+	if a.AuthMethod == nil {
+		return a.Name()
+	}
+
+	return a.AuthMethod.String()
+}
+
+// dispatchTransport routes sessions carrying a transportAuth to that auth's
+// HTTP client and everything else to the transport it replaced.
+type dispatchTransport struct {
+	fallback transport.Transport
+}
+
+func (d *dispatchTransport) NewUploadPackSession(ep *transport.Endpoint, auth transport.AuthMethod) (transport.UploadPackSession, error) {
+	if a, ok := auth.(*transportAuth); ok {
+		return githttp.NewClient(a.client).NewUploadPackSession(ep, a.AuthMethod)
+	}
+
+	return d.fallback.NewUploadPackSession(ep, auth)
+}
+
+func (d *dispatchTransport) NewReceivePackSession(ep *transport.Endpoint, auth transport.AuthMethod) (transport.ReceivePackSession, error) {
+	if a, ok := auth.(*transportAuth); ok {
+		return githttp.NewClient(a.client).NewReceivePackSession(ep, a.AuthMethod)
+	}
+
+	return d.fallback.NewReceivePackSession(ep, auth)
+}
+
+// authMethod returns the auth method to hand to go-git when this client
+// talks to url. It fails with ErrTransportReplaced if the dispatching
+// transport has since been replaced, as any other transport would ignore
+// the client's round tripper.
+func (c *Client) authMethod(url string) (transport.AuthMethod, error) {
+	rt := c.httpTransport()
+	if rt == nil || !strings.HasPrefix(url, "http") {
+		return c.auth, nil
+	}
+
+	for _, scheme := range []string{"http", "https"} {
+		if _, ok := client.Protocols[scheme].(*dispatchTransport); !ok {
+			return nil, ErrTransportReplaced
+		}
+	}
+
+	return &transportAuth{
+		AuthMethod: c.auth,
+		client:     &http.Client{Transport: rt},
+	}, nil
+}
@@ -0,0 +1,199 @@
+package bsdata
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/storage/memory"
+)
+
+// Version is a data release tag parsed as a semantic version.
+type Version struct {
+	// Tag is the tag name the version was parsed from.
+	Tag        string
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string
+}
+
+// ParseVersion parses a tag such as "1.7.0" or "v9.2.0-beta.1". Build
+// metadata is accepted and ignored.
+func ParseVersion(tag string) (Version, error) {
+	v := Version{Tag: tag}
+
+	s := strings.TrimPrefix(tag, "v")
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		s = s[:i]
+	}
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		v.Prerelease = s[i+1:]
+		s = s[:i]
+		if v.Prerelease == "" {
+			return Version{}, fmt.Errorf("bsdata: invalid version %q: empty prerelease", tag)
+		}
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return Version{}, fmt.Errorf("bsdata: invalid version %q", tag)
+	}
+
+	nums := []*int{&v.Major, &v.Minor, &v.Patch}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return Version{}, fmt.Errorf("bsdata: invalid version %q", tag)
+		}
+		*nums[i] = n
+	}
+
+	return v, nil
+}
+
+// Compare returns -1, 0 or +1 depending on whether v sorts before, with or
+// after o in semantic version order.
+func (v Version) Compare(o Version) int {
+	for _, d := range []int{v.Major - o.Major, v.Minor - o.Minor, v.Patch - o.Patch} {
+		if d != 0 {
+			return sign(d)
+		}
+	}
+
+	// a release sorts after its prereleases
+	switch {
+	case v.Prerelease == o.Prerelease:
+		return 0
+	case v.Prerelease == "":
+		return 1
+	case o.Prerelease == "":
+		return -1
+	}
+
+	a, b := strings.Split(v.Prerelease, "."), strings.Split(o.Prerelease, ".")
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if c := comparePrerelease(a[i], b[i]); c != 0 {
+			return c
+		}
+	}
+
+	return sign(len(a) - len(b))
+}
+
+func (v Version) String() string {
+	return v.Tag
+}
+
+// comparePrerelease compares single prerelease identifiers: numeric ones
+// numerically and below alphanumeric ones, which compare lexically.
+func comparePrerelease(a, b string) int {
+	an, aerr := strconv.Atoi(a)
+	bn, berr := strconv.Atoi(b)
+	switch {
+	case aerr == nil && berr == nil:
+		return sign(an - bn)
+	case aerr == nil:
+		return -1
+	case berr == nil:
+		return 1
+	}
+
+	return strings.Compare(a, b)
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+
+	return 0
+}
+
+// ListTags lists the data releases of repo in ascending version order.
+func ListTags(repo string) ([]Version, error) {
+	return defaultClient.ListTags(repo)
+}
+
+// Latest returns the newest data release of repo.
+func Latest(repo string) (Version, error) {
+	return defaultClient.Latest(repo)
+}
+
+// ListTags lists the data releases of repo in ascending version order.
+func (c *Client) ListTags(repo string) ([]Version, error) {
+	return c.ListTagsContext(context.Background(), repo)
+}
+
+// ListTagsContext lists the data releases of repo in ascending version
+// order. It reads the remote's refs without cloning, and skips tags that
+// are not semantic versions.
+func (c *Client) ListTagsContext(ctx context.Context, repo string) ([]Version, error) {
+	url := c.remoteURL(repo)
+	c.logger.Info("listing tags", "repo", repo, "url", url)
+
+	remote := git.NewRemote(memory.NewStorage(), &config.RemoteConfig{
+		Name: git.DefaultRemoteName,
+		URLs: []string{url},
+	})
+
+	auth, err := c.authMethod(url)
+	if err != nil {
+		return nil, err
+	}
+
+	refs, err := remote.ListContext(ctx, &git.ListOptions{Auth: auth})
+	if err != nil {
+		return nil, cloneErr(err, repo)
+	}
+
+	var versions []Version
+	for _, ref := range refs {
+		if !ref.Name().IsTag() {
+			continue
+		}
+
+		v, err := ParseVersion(ref.Name().Short())
+		if err != nil {
+			c.logger.Debug("skipping tag", "repo", repo, "tag", ref.Name().Short(), "error", err)
+			continue
+		}
+
+		versions = append(versions, v)
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].Compare(versions[j]) < 0
+	})
+
+	return versions, nil
+}
+
+// Latest returns the newest data release of repo.
+func (c *Client) Latest(repo string) (Version, error) {
+	return c.LatestContext(context.Background(), repo)
+}
+
+// LatestContext returns the newest data release of repo, ignoring
+// prereleases. It returns ErrTagNotFound if repo has no releases.
+func (c *Client) LatestContext(ctx context.Context, repo string) (Version, error) {
+	versions, err := c.ListTagsContext(ctx, repo)
+	if err != nil {
+		return Version{}, err
+	}
+
+	for i := len(versions) - 1; i >= 0; i-- {
+		if versions[i].Prerelease == "" {
+			return versions[i], nil
+		}
+	}
+
+	return Version{}, fmt.Errorf("%w: no releases in %s", ErrTagNotFound, repo)
+}
@@ -0,0 +1,85 @@
+package bsdata_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/myminicommission/go-bsdata"
+)
+
+func TestParseVersion(t *testing.T) {
+	v, err := bsdata.ParseVersion("v9.2.0-beta.1+build.5")
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if v.Major != 9 || v.Minor != 2 || v.Patch != 0 || v.Prerelease != "beta.1" {
+		t.Errorf("unexpected version %+v", v)
+	}
+
+	for _, tag := range []string{"latest", "1.2", "1.2.x", "1.2.3-"} {
+		if _, err := bsdata.ParseVersion(tag); err == nil {
+			t.Errorf("expected an error parsing %q", tag)
+		}
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	ordered := []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0", "1.0.1", "1.10.0", "v2.0.0"}
+	for i := 1; i < len(ordered); i++ {
+		a, _ := bsdata.ParseVersion(ordered[i-1])
+		b, _ := bsdata.ParseVersion(ordered[i])
+		if a.Compare(b) != -1 || b.Compare(a) != 1 {
+			t.Errorf("expected %s < %s", a, b)
+		}
+	}
+}
+
+func TestClientListTags(t *testing.T) {
+	base := newTestRemote(t, "test-repo", "1.10.0", "1.2.0", "v1.9.1", "2.0.0-rc.1", "not-a-release")
+	client := bsdata.NewClient(bsdata.WithBaseURL("file://" + base))
+
+	versions, err := client.ListTags("test-repo")
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	var tags []string
+	for _, v := range versions {
+		tags = append(tags, v.Tag)
+	}
+
+	want := []string{"1.2.0", "v1.9.1", "1.10.0", "2.0.0-rc.1"}
+	if len(tags) != len(want) {
+		t.Errorf("expected %v, got %v", want, tags)
+		t.FailNow()
+	}
+	for i := range want {
+		if tags[i] != want[i] {
+			t.Errorf("expected %v, got %v", want, tags)
+			break
+		}
+	}
+
+	latest, err := client.Latest("test-repo")
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if latest.Tag != "1.10.0" {
+		t.Errorf("expected latest release 1.10.0, got %s", latest.Tag)
+	}
+}
+
+func TestClientLatestNoReleases(t *testing.T) {
+	base := newTestRemote(t, "test-repo")
+	client := bsdata.NewClient(bsdata.WithBaseURL("file://" + base))
+
+	_, err := client.Latest("test-repo")
+	if !errors.Is(err, bsdata.ErrTagNotFound) {
+		t.Errorf("expected ErrTagNotFound, got %v", err)
+	}
+}